@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// applyConfig sets flags from the --config file, keyed by flag name. Flags given
+// on the command line take precedence over the file. A list sets a repeatable flag
+// once per item, e.g. `tags: [pets, store]`.
+func applyConfig(_ context.Context, cmd *cli.Command) error {
+	configFile := cmd.String("config")
+	if configFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if name == "config" {
+			return fmt.Errorf("config %s cannot set %q", configFile, name)
+		}
+		if cmd.IsSet(name) || values[name] == nil {
+			continue
+		}
+		for _, value := range configValues(values[name]) {
+			if err := cmd.Set(name, value); err != nil {
+				return fmt.Errorf("invalid option %q in config %s: %w", name, configFile, err)
+			}
+		}
+	}
+	return nil
+}
+
+// configValues converts a config value to the flag values it sets
+func configValues(value any) []string {
+	items, ok := value.([]any)
+	if !ok {
+		return []string{fmt.Sprint(value)}
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, fmt.Sprint(item))
+	}
+	return values
+}
@@ -0,0 +1,8 @@
+package cmd
+
+import "github.com/imtanmoy/openax/pkg/openax"
+
+// UnsupportedFormatError indicates that an unknown output format was requested.
+// It is an alias of openax.UnsupportedFormatError so CLI callers can match it
+// without importing the library package.
+type UnsupportedFormatError = openax.UnsupportedFormatError
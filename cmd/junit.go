@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes validation results as a JUnit XML report with one
+// test case per specification file
+func writeJUnitReport(path string, results []openax.ValidationResult) error {
+	suite := junitTestSuite{Name: "openax validate", Tests: len(results)}
+	for _, result := range results {
+		testCase := junitTestCase{Name: result.Path, ClassName: "openax.validate"}
+		if !result.Valid() {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: "validation failed", Text: result.Err.Error()}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0600)
+}
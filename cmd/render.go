@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+)
+
+// renderFindings writes lint findings to w. Plain output prints one finding per
+// line; pretty output groups findings by location and ends with a summary count.
+func renderFindings(w io.Writer, findings []openax.LintFinding, pretty bool) {
+	if !pretty {
+		for _, finding := range findings {
+			fmt.Fprintln(w, finding.String())
+		}
+		return
+	}
+
+	// Group by location, keeping the order in which locations first appear
+	var locations []string
+	groups := make(map[string][]openax.LintFinding)
+	for _, finding := range findings {
+		location := "(no location)"
+		if finding.Location != nil {
+			location = finding.Location.String()
+		}
+		if _, ok := groups[location]; !ok {
+			locations = append(locations, location)
+		}
+		groups[location] = append(groups[location], finding)
+	}
+
+	errors, warnings := 0, 0
+	for i, location := range locations {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, location)
+		for _, finding := range groups[location] {
+			severity := finding.Severity
+			if severity == "" {
+				severity = openax.SeverityError
+			}
+			if severity == openax.SeverityWarning {
+				warnings++
+			} else {
+				errors++
+			}
+			fmt.Fprintf(w, "  %-7s %s (%s)\n", severity, finding.Message, finding.Rule)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d problem(s): %d error(s), %d warning(s)\n", len(findings), errors, warnings)
+}
+
+// isTerminal reports whether w is a file attached to a terminal. It is a variable
+// so tests can stand in for a terminal.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+)
+
+func TestRenderFindings(t *testing.T) {
+	findings := []openax.LintFinding{
+		{
+			Rule:     openax.RuleRequiredProperties,
+			Severity: openax.SeverityError,
+			Message:  `schema Pet requires undefined property "name"`,
+			Location: &openax.SourceLocation{Path: "components.schemas.Pet"},
+		},
+		{
+			Rule:     openax.RuleResponseSchema,
+			Severity: openax.SeverityWarning,
+			Message:  "response 200 of GET /pets declares application/json without a schema",
+			Location: &openax.SourceLocation{Path: "paths./pets.get.responses.200.content.application/json"},
+		},
+		{
+			Rule:     openax.RuleRequiredProperties,
+			Severity: openax.SeverityError,
+			Message:  `schema Pet requires undefined property "age"`,
+			Location: &openax.SourceLocation{Path: "components.schemas.Pet"},
+		},
+	}
+
+	t.Run("pretty output groups by location", func(t *testing.T) {
+		var buf bytes.Buffer
+		renderFindings(&buf, findings, true)
+
+		expected := `path components.schemas.Pet
+  error   schema Pet requires undefined property "name" (required-properties)
+  error   schema Pet requires undefined property "age" (required-properties)
+
+path paths./pets.get.responses.200.content.application/json
+  warning response 200 of GET /pets declares application/json without a schema (response-schema)
+
+3 problem(s): 2 error(s), 1 warning(s)
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("plain output prints one finding per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		renderFindings(&buf, findings, false)
+
+		expected := findings[0].String() + "\n" + findings[1].String() + "\n" + findings[2].String() + "\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestPrettyErrorsFlag(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+`), 0600))
+
+	run := func(t *testing.T) string {
+		app := NewApp()
+		var stderr bytes.Buffer
+		app.ErrWriter = &stderr
+
+		err := app.Run(context.Background(), []string{
+			"openax", "-i", specPath, "--check-required", "--check-response-schemas", "--pretty-errors", "--dry-run",
+		})
+		require.EqualError(t, err, "lint found 2 issue(s)")
+		return stderr.String()
+	}
+
+	terminalCheck := isTerminal
+	t.Run("terminal", func(t *testing.T) {
+		isTerminal = func(io.Writer) bool { return true }
+		t.Cleanup(func() { isTerminal = terminalCheck })
+
+		expected := `path paths./pets.get.responses.200.content.application/json
+  warning response 200 of GET /pets declares application/json without a schema (response-schema)
+
+path components.schemas.Pet
+  error   required property "name" is not defined in properties (required-properties)
+
+2 problem(s): 1 error(s), 1 warning(s)
+`
+		assert.Equal(t, expected, run(t))
+	})
+
+	t.Run("falls back to plain output", func(t *testing.T) {
+		expected := `[response-schema] response 200 of GET /pets declares application/json without a schema at path paths./pets.get.responses.200.content.application/json
+[required-properties] required property "name" is not defined in properties at path components.schemas.Pet
+`
+		assert.Equal(t, expected, run(t), "Output that is not a terminal should stay plain")
+	})
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -25,19 +31,27 @@ the referenced components, and writes the result to JSON or YAML.`,
 			&cli.StringFlag{
 				Name:     "input",
 				Aliases:  []string{"i"},
-				Usage:    "Input OpenAPI spec file (required)",
+				Usage:    "Input OpenAPI spec file, or a directory of specs (required)",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "Output file (stdout if not specified)",
+				Usage:   "Output file (stdout if not specified); output directory when the input is a directory",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "YAML or JSON file of flag values keyed by flag name; flags on the command line take precedence",
+			},
+			&cli.StringFlag{
+				Name:  "overlay",
+				Usage: "Overlay spec applied on top of the input before filtering (overlay wins on conflict)",
 			},
 			&cli.StringFlag{
 				Name:    "format",
 				Aliases: []string{"f"},
 				Value:   "yaml",
-				Usage:   "Output format: json or yaml",
+				Usage:   "Output format: json or yaml (comma-separated for multiple, e.g. json,yaml)",
 			},
 			&cli.StringSliceFlag{
 				Name:    "paths",
@@ -51,53 +65,286 @@ the referenced components, and writes the result to JSON or YAML.`,
 			&cli.StringSliceFlag{
 				Name:    "tags",
 				Aliases: []string{"t"},
-				Usage:   "Filter by tags",
+				Usage:   "Filter by tags (\"admin/*\" selects child tags, \"admin/**\" all descendants)",
+			},
+			&cli.StringFlag{
+				Name:  "tag-description-match",
+				Usage: "Filter by tags whose description matches this regular expression",
+			},
+			&cli.StringSliceFlag{
+				Name:  "schemas",
+				Usage: "Filter to operations using these component schemas (e.g., Pet, Order)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "has-parameters",
+				Usage: "Filter to operations declaring a parameter with one of these names (e.g., tenantId)",
 			},
 			&cli.BoolFlag{
 				Name:  "validate-only",
 				Usage: "Only validate the spec without filtering",
 			},
+			&cli.StringFlag{
+				Name:  "junit",
+				Usage: "Write a JUnit XML report when validating a directory with --validate-only",
+			},
+			&cli.BoolFlag{
+				Name:    "recursive",
+				Aliases: []string{"r"},
+				Usage:   "Include subdirectories when validating or filtering a directory",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Maximum number of specs filtered in parallel when the input is a directory (default: number of CPUs)",
+			},
+			&cli.BoolFlag{
+				Name:  "check-response-schemas",
+				Usage: "Fail if any response media type is declared without a schema",
+			},
+			&cli.BoolFlag{
+				Name:  "check-required",
+				Usage: "Fail if any schema requires a property it does not define",
+			},
+			&cli.BoolFlag{
+				Name:  "check-unused-components",
+				Usage: "Fail if any schema, parameter, request body, or response component is never referenced",
+			},
+			&cli.BoolFlag{
+				Name:  "check-duplicate-paths",
+				Usage: "Fail if paths differ only in path parameter names (e.g., /users/{id} and /users/{userId})",
+			},
+			&cli.BoolFlag{
+				Name:  "check-servers",
+				Usage: "Fail if any server URL is empty or relative (\"/\" is allowed)",
+			},
+			&cli.BoolFlag{
+				Name:  "check-request-body-required",
+				Usage: "Fail if any operation request body is not marked required",
+			},
+			&cli.BoolFlag{
+				Name:  "pretty-errors",
+				Usage: "Group lint findings by location with severities and a summary (terminal only)",
+			},
+			&cli.BoolFlag{
+				Name:  "require-request-body",
+				Usage: "Filter to operations whose request body is marked required",
+			},
+			&cli.IntFlag{
+				Name:  "max-paths-per-tag",
+				Usage: "Keep at most this many matched paths per tag, chosen deterministically (0 = no limit)",
+			},
 			&cli.BoolFlag{
 				Name:    "prune-components",
 				Aliases: []string{"prune"},
 				Usage:   "Remove unused components from the filtered specification",
 			},
+			&cli.BoolFlag{
+				Name:  "prune-security-schemes",
+				Usage: "Also remove unused security schemes and OAuth2 scopes when pruning",
+			},
+			&cli.StringSliceFlag{
+				Name:  "no-prune",
+				Usage: "Component categories to keep intact when pruning (e.g., schemas, responses)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "strip-extensions",
+				Usage: "Remove vendor extensions by name or prefix (e.g., x-internal*)",
+			},
+			&cli.StringFlag{
+				Name:  "operation-id-case",
+				Usage: "Rewrite operationIds to a naming convention: camel, snake, or kebab",
+			},
+			&cli.BoolFlag{
+				Name:  "follow-links",
+				Usage: "Include operations targeted by response links of matched operations",
+			},
+			&cli.BoolFlag{
+				Name:  "include-parent-paths",
+				Usage: "Also include the collection path of retained item paths (e.g., /pets for /pets/{id})",
+			},
+			&cli.BoolFlag{
+				Name:  "keep-empty-paths",
+				Usage: "Keep retained paths that have no operations",
+			},
+			&cli.BoolFlag{
+				Name:  "keep-empty-content",
+				Usage: "Do not warn about responses that content-type folding left without content",
+			},
+			&cli.BoolFlag{
+				Name:  "keep-unused-tags",
+				Usage: "Keep all top-level tags instead of only those used by retained operations",
+			},
+			&cli.BoolFlag{
+				Name:  "resolve-server-variables",
+				Usage: "Substitute server variable defaults into server URLs and drop the variables",
+			},
+			&cli.StringFlag{
+				Name:  "version-suffix",
+				Usage: "Append a suffix to info.version of the output (e.g. +public)",
+			},
+			&cli.StringFlag{
+				Name:  "canonical-response-content-type",
+				Usage: "Reduce every response to this content type, reusing equivalent schemas (e.g. application/json)",
+			},
+			&cli.StringFlag{
+				Name:  "include-examples-from",
+				Usage: "JSON or YAML file mapping schema names to examples to attach to the output",
+			},
+			&cli.BoolFlag{
+				Name:  "embed-provenance",
+				Usage: "Record the applied filters, tool version, and timestamp in an x-openax extension",
+			},
+			&cli.BoolFlag{
+				Name:  "toc",
+				Usage: "Write a JSON table of contents (tags and their operations) instead of the spec",
+			},
+			&cli.StringFlag{
+				Name:  "manifest",
+				Usage: "Also write a JSON manifest of the components the filtered spec depends on to this file",
+			},
+			&cli.StringFlag{
+				Name:  "csv",
+				Usage: "Also write a CSV of the retained operations (method, path, operationId, tags, summary, deprecated) to this file",
+			},
+			&cli.StringFlag{
+				Name:  "changed-schemas",
+				Usage: "Output only the schemas that changed since this base spec, with the schemas they reference",
+			},
+			&cli.BoolFlag{
+				Name:  "list-external",
+				Usage: "Print the external $ref targets of the input file instead of filtering",
+			},
+			&cli.BoolFlag{
+				Name:  "explain-prune",
+				Usage: "Print why each component survives pruning instead of writing output",
+			},
+			&cli.BoolFlag{
+				Name:  "print-options",
+				Usage: "Print the effective filter and output options as JSON to stderr before running",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Abort loading, validation, and filtering after this long (e.g., 30s; 0 = no limit)",
+			},
+			&cli.IntFlag{
+				Name:  "retries",
+				Usage: "Retry failed remote fetches, including external $refs, this many times",
+			},
 			&cli.BoolFlag{
 				Name:    "dry-run",
 				Aliases: []string{"n"},
 				Usage:   "Preview filtering results without writing the output file",
 			},
 		},
+		Before: applyConfig,
 		Action: runFilter,
 	}
 }
 
+// runFilter runs the command, bounded by --timeout when it is set
 func runFilter(ctx context.Context, cmd *cli.Command) error {
+	timeout := cmd.Duration("timeout")
+	if timeout <= 0 {
+		return run(ctx, cmd)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := run(ctx, cmd)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return err
+}
+
+func run(ctx context.Context, cmd *cli.Command) error {
 	inputFile := cmd.String("input")
 
-	client := openax.NewWithOptions(openax.LoadOptions{
+	loadOpts := openax.LoadOptions{
 		AllowExternalRefs: true,
 		Context:           ctx,
-	})
+		Retries:           int(cmd.Int("retries")),
+	}
+	client := openax.NewWithOptions(loadOpts)
+
+	// Listing reads the file as is, so it must not depend on loading succeeding
+	if cmd.Bool("list-external") {
+		return listExternalRefs(inputFile)
+	}
+
+	if info, err := os.Stat(inputFile); err == nil && info.IsDir() {
+		return runDir(loadOpts, inputFile, cmd)
+	}
+
+	// The input is loaded once; every step below works on this document
+	source, err := client.LoadFromSource(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	if lintOpts := lintOptionsFromFlags(cmd); lintOpts != (openax.LintOptions{}) {
+		if err := runLint(cmd.Root().ErrWriter, client, source, lintOpts, cmd.Bool("pretty-errors")); err != nil {
+			return err
+		}
+	}
 
 	if cmd.Bool("validate-only") {
-		if err := client.ValidateOnly(inputFile); err != nil {
+		if err := client.Validate(source); err != nil {
 			return fmt.Errorf("validation failed: %w", err)
 		}
 		fmt.Println("OpenAPI spec is valid")
 		return nil
 	}
 
-	filteredDoc, err := client.LoadAndFilter(inputFile, openax.FilterOptions{
-		Paths:           cmd.StringSlice("paths"),
-		Operations:      cmd.StringSlice("operations"),
-		Tags:            cmd.StringSlice("tags"),
-		PruneComponents: cmd.Bool("prune-components"),
-	})
+	opts := filterOptionsFromFlags(cmd)
+
+	if cmd.Bool("print-options") {
+		if err := printOptions(cmd.Root().ErrWriter, cmd, opts); err != nil {
+			return err
+		}
+	}
+
+	doc, err := prepareInput(client, source, cmd.String("overlay"))
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("explain-prune") {
+		return explainPrune(client, doc, opts)
+	}
+
+	if cmd.Bool("toc") {
+		return writeTableOfContents(client, doc, cmd.String("output"), opts)
+	}
+
+	var filteredDoc *openapi3.T
+	if baseFile := cmd.String("changed-schemas"); baseFile != "" {
+		filteredDoc, err = filterChangedSchemas(client, baseFile, doc, opts)
+	} else {
+		filteredDoc, err = client.Filter(doc, opts)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to filter spec: %w", err)
 	}
 
+	if manifestFile := cmd.String("manifest"); manifestFile != "" && !cmd.Bool("dry-run") {
+		if err := writeManifest(filteredDoc, manifestFile); err != nil {
+			return err
+		}
+	}
+
+	if csvFile := cmd.String("csv"); csvFile != "" && !cmd.Bool("dry-run") {
+		if err := writeEndpointsCSV(filteredDoc, csvFile); err != nil {
+			return err
+		}
+	}
+
+	if examplesFile := cmd.String("include-examples-from"); examplesFile != "" {
+		if err := includeExamples(filteredDoc, examplesFile); err != nil {
+			return err
+		}
+	}
+
 	// Handle dry run mode
 	if cmd.Bool("dry-run") {
 		return showDryRunSummary(filteredDoc, cmd)
@@ -106,6 +353,305 @@ func runFilter(ctx context.Context, cmd *cli.Command) error {
 	return writeOutput(cmd, filteredDoc)
 }
 
+// runDir validates or filters every spec in a directory
+func runDir(loadOpts openax.LoadOptions, dir string, cmd *cli.Command) error {
+	if cmd.Bool("validate-only") {
+		return validateDir(dir, cmd.Bool("recursive"), cmd.String("junit"))
+	}
+
+	opts := filterOptionsFromFlags(cmd)
+
+	if cmd.Bool("print-options") {
+		if err := printOptions(cmd.Root().ErrWriter, cmd, opts); err != nil {
+			return err
+		}
+	}
+
+	return filterDir(loadOpts, dir, cmd, opts)
+}
+
+// listExternalRefs prints the external references of a local spec file, one per line.
+// The file is read as is, so external references need not be loadable.
+func listExternalRefs(inputFile string) error {
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	refs, err := openax.ExternalRefs(data)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		fmt.Println(ref)
+	}
+	return nil
+}
+
+// validateDir validates every spec in a directory, printing a summary and
+// optionally writing a JUnit XML report
+func validateDir(dir string, recursive bool, junitFile string) error {
+	results := openax.ValidateDir(dir, recursive)
+
+	failures := 0
+	for _, result := range results {
+		if result.Valid() {
+			fmt.Printf("✓ %s\n", result.Path)
+			continue
+		}
+		failures++
+		fmt.Printf("✗ %s: %v\n", result.Path, result.Err)
+	}
+	fmt.Printf("%d of %d spec(s) valid\n", len(results)-failures, len(results))
+
+	if junitFile != "" {
+		if err := writeJUnitReport(junitFile, results); err != nil {
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("validation failed for %d spec(s)", failures)
+	}
+	return nil
+}
+
+// filterDir filters every spec in a directory in parallel and writes each result to
+// the output directory under its relative path, with the extension of each format.
+// Each spec is loaded, filtered, and written by one worker, so at most --concurrency
+// specs are held in memory at once.
+func filterDir(loadOpts openax.LoadOptions, dir string, cmd *cli.Command, opts openax.FilterOptions) error {
+	outputDir := cmd.String("output")
+	dryRun := cmd.Bool("dry-run")
+	if outputDir == "" && !dryRun {
+		return fmt.Errorf("filtering a directory requires --output")
+	}
+
+	paths, err := openax.SpecFiles(dir, cmd.Bool("recursive"))
+	if err != nil {
+		return fmt.Errorf("failed to read input directory: %w", err)
+	}
+
+	formats := parseFormats(cmd.String("format"))
+	pathCounts := make([]int, len(paths))
+
+	load := func(i int) (*openapi3.T, error) {
+		// Loaders are not safe for concurrent use, so each spec gets its own client
+		doc, err := loadInput(openax.NewWithOptions(loadOpts), paths[i], cmd.String("overlay"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", paths[i], err)
+		}
+		return doc, nil
+	}
+
+	write := func(i int, filtered *openapi3.T) error {
+		if dryRun {
+			pathCounts[i] = filtered.Paths.Len()
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, paths[i])
+		if err != nil {
+			return err
+		}
+		base := filepath.Join(outputDir, strings.TrimSuffix(rel, filepath.Ext(rel)))
+		if err := os.MkdirAll(filepath.Dir(base), 0700); err != nil {
+			return err
+		}
+		for _, format := range formats {
+			data, err := openax.Encode(filtered, format)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(base+"."+format, data, 0600); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := openax.FilterEach(len(paths), opts, int(cmd.Int("concurrency")), load, write); err != nil {
+		return fmt.Errorf("failed to filter specs: %w", err)
+	}
+
+	if dryRun {
+		for i, path := range paths {
+			fmt.Printf("%s: %d path(s)\n", path, pathCounts[i])
+		}
+	}
+	return nil
+}
+
+func filterOptionsFromFlags(cmd *cli.Command) openax.FilterOptions {
+	opts := openax.FilterOptions{
+		Paths:                        cmd.StringSlice("paths"),
+		Operations:                   cmd.StringSlice("operations"),
+		Tags:                         cmd.StringSlice("tags"),
+		TagDescriptionMatch:          cmd.String("tag-description-match"),
+		Schemas:                      cmd.StringSlice("schemas"),
+		HasParameters:                cmd.StringSlice("has-parameters"),
+		RequireRequestBody:           cmd.Bool("require-request-body"),
+		MaxPathsPerTag:               int(cmd.Int("max-paths-per-tag")),
+		PruneComponents:              cmd.Bool("prune-components"),
+		PruneSecuritySchemes:         cmd.Bool("prune-security-schemes"),
+		NoPrune:                      cmd.StringSlice("no-prune"),
+		StripExtensions:              cmd.StringSlice("strip-extensions"),
+		OperationIDCase:              openax.OperationIDCase(cmd.String("operation-id-case")),
+		FollowLinks:                  cmd.Bool("follow-links"),
+		IncludeParentPaths:           cmd.Bool("include-parent-paths"),
+		KeepEmptyPaths:               cmd.Bool("keep-empty-paths"),
+		KeepEmptyContent:             cmd.Bool("keep-empty-content"),
+		ResolveServerVariables:       cmd.Bool("resolve-server-variables"),
+		EmbedProvenance:              cmd.Bool("embed-provenance"),
+		CanonicalResponseContentType: cmd.String("canonical-response-content-type"),
+		VersionSuffix:                cmd.String("version-suffix"),
+	}
+
+	if cmd.IsSet("keep-unused-tags") {
+		stripUnused := !cmd.Bool("keep-unused-tags")
+		opts.StripUnusedTags = &stripUnused
+	}
+
+	return opts
+}
+
+// effectiveOptions is the resolved configuration printed by --print-options
+type effectiveOptions struct {
+	Input   string               `json:"input"`
+	Config  string               `json:"config,omitempty"`
+	Overlay string               `json:"overlay,omitempty"`
+	Filter  openax.FilterOptions `json:"filter"`
+	Output  outputOptions        `json:"output"`
+}
+
+type outputOptions struct {
+	Format string `json:"format"`
+	File   string `json:"file,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+// printOptions writes the options the run will use as indented JSON
+func printOptions(w io.Writer, cmd *cli.Command, opts openax.FilterOptions) error {
+	data, err := json.MarshalIndent(effectiveOptions{
+		Input:   cmd.String("input"),
+		Config:  cmd.String("config"),
+		Overlay: cmd.String("overlay"),
+		Filter:  opts,
+		Output: outputOptions{
+			Format: cmd.String("format"),
+			File:   cmd.String("output"),
+			DryRun: cmd.Bool("dry-run"),
+		},
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode options: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// filterChangedSchemas reduces the input to the schemas that changed since the base spec
+func filterChangedSchemas(client *openax.Client, baseFile string, current *openapi3.T, opts openax.FilterOptions) (*openapi3.T, error) {
+	base, err := client.LoadFromSource(baseFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base spec: %w", err)
+	}
+	return openax.FilterChangedSchemas(base, current, opts)
+}
+
+// loadInput loads and validates the input, applying the overlay on top if one is given
+func loadInput(client *openax.Client, inputFile, overlayFile string) (*openapi3.T, error) {
+	base, err := client.LoadFromSource(inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec: %w", err)
+	}
+	return prepareInput(client, base, overlayFile)
+}
+
+// prepareInput applies the overlay to a loaded spec if one is given and validates the result
+func prepareInput(client *openax.Client, base *openapi3.T, overlayFile string) (*openapi3.T, error) {
+	if overlayFile == "" {
+		if err := client.Validate(base); err != nil {
+			return nil, fmt.Errorf("spec validation failed: %w", err)
+		}
+		return base, nil
+	}
+
+	overlay, err := client.LoadFromSource(overlayFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load overlay: %w", err)
+	}
+
+	merged, err := openax.Overlay(base, overlay)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Validate(merged); err != nil {
+		return nil, fmt.Errorf("spec validation failed: %w", err)
+	}
+
+	return merged, nil
+}
+
+// explainPrune prints each component retained by pruning with the places that reference it
+func explainPrune(client *openax.Client, doc *openapi3.T, opts openax.FilterOptions) error {
+	reasons := client.ExplainPrune(doc, opts)
+	if reasons == nil {
+		// Filtering failed; run it again to surface the error
+		_, err := client.Filter(doc, opts)
+		return fmt.Errorf("failed to filter spec: %w", err)
+	}
+
+	for _, component := range slices.Sorted(maps.Keys(reasons)) {
+		fmt.Printf("%s kept by:\n", component)
+		for _, reason := range reasons[component] {
+			fmt.Printf("  • %s\n", reason)
+		}
+	}
+	return nil
+}
+
+func lintOptionsFromFlags(cmd *cli.Command) openax.LintOptions {
+	return openax.LintOptions{
+		CheckResponseSchemas:     cmd.Bool("check-response-schemas"),
+		CheckRequired:            cmd.Bool("check-required"),
+		CheckUnusedComponents:    cmd.Bool("check-unused-components"),
+		CheckDuplicatePaths:      cmd.Bool("check-duplicate-paths"),
+		CheckServers:             cmd.Bool("check-servers"),
+		CheckRequestBodyRequired: cmd.Bool("check-request-body-required"),
+	}
+}
+
+func runLint(w io.Writer, client *openax.Client, doc *openapi3.T, opts openax.LintOptions, pretty bool) error {
+	// Pretty output is only used when a person is reading it
+	findings := client.Lint(doc, opts)
+	renderFindings(w, findings, pretty && isTerminal(w))
+
+	if len(findings) > 0 {
+		return fmt.Errorf("lint found %d issue(s)", len(findings))
+	}
+	return nil
+}
+
+func includeExamples(doc *openapi3.T, examplesFile string) error {
+	data, err := os.ReadFile(examplesFile)
+	if err != nil {
+		return fmt.Errorf("failed to read examples: %w", err)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var examples map[string]any
+	if err := yaml.Unmarshal(data, &examples); err != nil {
+		return fmt.Errorf("failed to parse examples: %w", err)
+	}
+
+	for _, name := range openax.InjectExamples(doc, examples) {
+		fmt.Fprintf(os.Stderr, "warning: no schema named %q for example in %s\n", name, examplesFile)
+	}
+	return nil
+}
+
 func showDryRunSummary(doc *openapi3.T, cmd *cli.Command) error {
 	fmt.Println("🔍 Dry Run Mode - Filtering Results Summary")
 	fmt.Println("==========================================")
@@ -205,8 +751,70 @@ func showAppliedFilters(cmd *cli.Command) {
 	if tags := cmd.StringSlice("tags"); len(tags) > 0 {
 		fmt.Printf("  • Tags: %v\n", tags)
 	}
+	if pattern := cmd.String("tag-description-match"); pattern != "" {
+		fmt.Printf("  • Tag descriptions matching: %s\n", pattern)
+	}
+	if schemas := cmd.StringSlice("schemas"); len(schemas) > 0 {
+		fmt.Printf("  • Schemas: %v\n", schemas)
+	}
+	if params := cmd.StringSlice("has-parameters"); len(params) > 0 {
+		fmt.Printf("  • Parameters: %v\n", params)
+	}
+	if cmd.Bool("require-request-body") {
+		fmt.Println("  • Requiring request bodies: enabled")
+	}
+	if maxPaths := cmd.Int("max-paths-per-tag"); maxPaths > 0 {
+		fmt.Printf("  • Max paths per tag: %d\n", maxPaths)
+	}
 	if cmd.Bool("prune-components") {
 		fmt.Println("  • Component pruning: enabled")
+		if cmd.Bool("prune-security-schemes") {
+			fmt.Println("  • Security scheme pruning: enabled")
+		}
+		if noPrune := cmd.StringSlice("no-prune"); len(noPrune) > 0 {
+			fmt.Printf("  • Not pruning: %v\n", noPrune)
+		}
+	}
+	if extensions := cmd.StringSlice("strip-extensions"); len(extensions) > 0 {
+		fmt.Printf("  • Stripping extensions: %v\n", extensions)
+	}
+	if idCase := cmd.String("operation-id-case"); idCase != "" {
+		fmt.Printf("  • OperationId case: %s\n", idCase)
+	}
+	if cmd.Bool("follow-links") {
+		fmt.Println("  • Following response links: enabled")
+	}
+
+	if cmd.Bool("include-parent-paths") {
+		fmt.Println("  • Including parent paths: enabled")
+	}
+
+	if cmd.Bool("keep-empty-paths") {
+		fmt.Println("  • Keeping empty paths: enabled")
+	}
+
+	if cmd.Bool("keep-empty-content") {
+		fmt.Println("  • Empty content warnings: disabled")
+	}
+
+	if cmd.Bool("keep-unused-tags") {
+		fmt.Println("  • Keeping unused tags: enabled")
+	}
+
+	if cmd.Bool("resolve-server-variables") {
+		fmt.Println("  • Resolving server variables: enabled")
+	}
+
+	if contentType := cmd.String("canonical-response-content-type"); contentType != "" {
+		fmt.Printf("  • Canonical response content type: %s\n", contentType)
+	}
+
+	if suffix := cmd.String("version-suffix"); suffix != "" {
+		fmt.Printf("  • Version suffix: %s\n", suffix)
+	}
+
+	if baseFile := cmd.String("changed-schemas"); baseFile != "" {
+		fmt.Printf("  • Schemas changed since: %s\n", baseFile)
 	}
 
 	if hasNoFilters(cmd) {
@@ -218,7 +826,12 @@ func showAppliedFilters(cmd *cli.Command) {
 func hasNoFilters(cmd *cli.Command) bool {
 	return len(cmd.StringSlice("paths")) == 0 &&
 		len(cmd.StringSlice("operations")) == 0 &&
-		len(cmd.StringSlice("tags")) == 0
+		len(cmd.StringSlice("tags")) == 0 &&
+		cmd.String("tag-description-match") == "" &&
+		len(cmd.StringSlice("schemas")) == 0 &&
+		len(cmd.StringSlice("has-parameters")) == 0 &&
+		!cmd.Bool("require-request-body") &&
+		cmd.String("changed-schemas") == ""
 }
 
 func showOutputConfiguration(cmd *cli.Command) {
@@ -232,30 +845,112 @@ func showOutputConfiguration(cmd *cli.Command) {
 	}
 }
 
-func writeOutput(cmd *cli.Command, doc *openapi3.T) error {
-	var data []byte
-	var err error
+// writeTableOfContents writes the filtered operations grouped by tag as JSON
+func writeTableOfContents(client *openax.Client, doc *openapi3.T, outputFile string, opts openax.FilterOptions) error {
+	toc := openax.TableOfContents(doc, opts)
+	if toc == nil {
+		// Either nothing matched or the options are invalid; filtering tells them apart
+		if _, err := client.Filter(doc, opts); err != nil {
+			return fmt.Errorf("failed to filter spec: %w", err)
+		}
+		toc = []openax.TagSection{}
+	}
 
-	format := cmd.String("format")
-	switch strings.ToLower(format) {
-	case "json":
-		data, err = json.MarshalIndent(doc, "", "  ")
-	case "yaml", "yml":
-		data, err = yaml.Marshal(doc)
-	default:
-		return fmt.Errorf("unsupported output format: %s", format)
+	data, err := json.MarshalIndent(toc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode table of contents: %w", err)
 	}
 
+	if outputFile == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	return os.WriteFile(outputFile, append(data, '\n'), 0600)
+}
+
+// writeManifest writes the components of the filtered spec as JSON
+func writeManifest(filtered *openapi3.T, manifestFile string) error {
+	data, err := json.MarshalIndent(openax.ListComponents(filtered), "", "  ")
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestFile, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
 	}
+	return nil
+}
 
+// writeEndpointsCSV writes one CSV row per operation of the filtered spec to a file
+func writeEndpointsCSV(filtered *openapi3.T, csvFile string) error {
+	var buf bytes.Buffer
+	if err := openax.WriteEndpointsCSV(&buf, filtered); err != nil {
+		return fmt.Errorf("failed to encode endpoints CSV: %w", err)
+	}
+	if err := os.WriteFile(csvFile, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write endpoints CSV: %w", err)
+	}
+	return nil
+}
+
+// formatExtensions are the output file extensions that already name a format
+var formatExtensions = []string{".json", ".yaml", ".yml"}
+
+func writeOutput(cmd *cli.Command, doc *openapi3.T) error {
+	formats := parseFormats(cmd.String("format"))
 	outputFile := cmd.String("output")
+
+	if len(formats) == 1 {
+		data, err := openax.Encode(doc, formats[0])
+		if err != nil {
+			return err
+		}
+
+		if outputFile == "" {
+			fmt.Print(string(data))
+			return nil
+		}
+		return os.WriteFile(outputFile, data, 0600)
+	}
+
+	// Multiple formats are written side by side using the output as a base name
 	if outputFile == "" {
-		fmt.Print(string(data))
-	} else {
-		err = os.WriteFile(outputFile, data, 0600)
+		return fmt.Errorf("multiple output formats require --output")
+	}
+	// Dotted base names such as api.v2 are fine; only a format extension is ambiguous
+	if ext := filepath.Ext(outputFile); slices.Contains(formatExtensions, strings.ToLower(ext)) {
+		return fmt.Errorf("output %q has extension %q; use a base name without extension with multiple formats", outputFile, ext)
 	}
 
-	return err
+	// Encode everything up front so an unsupported format leaves no partial output
+	encoded := make([][]byte, len(formats))
+	for i, format := range formats {
+		data, err := openax.Encode(doc, format)
+		if err != nil {
+			return err
+		}
+		encoded[i] = data
+	}
+
+	for i, format := range formats {
+		if err := os.WriteFile(outputFile+"."+format, encoded[i], 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseFormats splits a comma-separated --format value into normalized format names.
+func parseFormats(value string) []string {
+	var formats []string
+	for _, format := range strings.Split(value, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format != "" && !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		formats = append(formats, value)
+	}
+	return formats
 }
@@ -1,12 +1,21 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/imtanmoy/openax/cmd"
+	"github.com/imtanmoy/openax/pkg/openax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -89,6 +98,26 @@ func TestCLIIntegration(t *testing.T) {
 			args:        []string{"openax", "-i", specPath, "--tags", "users", "--format", "json"},
 			expectError: false,
 		},
+		{
+			name:        "check response schemas",
+			args:        []string{"openax", "-i", specPath, "--check-response-schemas", "--format", "json"},
+			expectError: false,
+		},
+		{
+			name:        "check required properties",
+			args:        []string{"openax", "-i", specPath, "--check-required", "--format", "json"},
+			expectError: false,
+		},
+		{
+			name:        "list external refs",
+			args:        []string{"openax", "-i", specPath, "--list-external"},
+			expectError: false,
+		},
+		{
+			name:        "explain prune",
+			args:        []string{"openax", "-i", specPath, "--tags", "users", "--explain-prune"},
+			expectError: false,
+		},
 		{
 			name:        "missing input file",
 			args:        []string{"openax", "--tags", "users"},
@@ -117,3 +146,429 @@ func TestCLIIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestUnsupportedFormatError(t *testing.T) {
+	app := cmd.NewApp()
+
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	err := app.Run(context.Background(), []string{"openax", "-i", specPath, "--format", "toml"})
+	require.Error(t, err, "Expected error for unsupported format")
+
+	var formatErr cmd.UnsupportedFormatError
+	require.True(t, errors.As(err, &formatErr), "Expected UnsupportedFormatError, got %T", err)
+	assert.Equal(t, "toml", formatErr.Format)
+	assert.Contains(t, formatErr.Supported, "json")
+	assert.Contains(t, formatErr.Supported, "yaml")
+	assert.Contains(t, err.Error(), "unsupported output format: toml")
+}
+
+func TestMultipleOutputFormats(t *testing.T) {
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+
+	t.Run("writes one file per format", func(t *testing.T) {
+		app := cmd.NewApp()
+		base := filepath.Join(t.TempDir(), "spec")
+
+		err := app.Run(context.Background(), []string{"openax", "-i", specPath, "--format", "json,yaml", "--output", base})
+		require.NoError(t, err)
+
+		assert.FileExists(t, base+".json")
+		assert.FileExists(t, base+".yaml")
+	})
+
+	t.Run("rejects fixed filename", func(t *testing.T) {
+		app := cmd.NewApp()
+		output := filepath.Join(t.TempDir(), "spec.yaml")
+
+		err := app.Run(context.Background(), []string{"openax", "-i", specPath, "--format", "json,yaml", "--output", output})
+		require.Error(t, err)
+		assert.NoFileExists(t, output)
+	})
+
+	t.Run("accepts dotted base name", func(t *testing.T) {
+		app := cmd.NewApp()
+		base := filepath.Join(t.TempDir(), "api.v2")
+
+		err := app.Run(context.Background(), []string{"openax", "-i", specPath, "--format", "json,yaml", "--output", base})
+		require.NoError(t, err)
+
+		assert.FileExists(t, base+".json")
+		assert.FileExists(t, base+".yaml")
+	})
+
+	t.Run("requires output", func(t *testing.T) {
+		app := cmd.NewApp()
+
+		err := app.Run(context.Background(), []string{"openax", "-i", specPath, "--format", "json,yaml"})
+		require.Error(t, err)
+	})
+}
+
+func TestIncludeExamplesFrom(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	examplesPath := filepath.Join(dir, "examples.yaml")
+	require.NoError(t, os.WriteFile(examplesPath, []byte("Pet:\n  id: 10\n  name: doggie\nUnknown: {}\n"), 0600))
+
+	specPath := filepath.Join("..", "testdata", "specs", "petstore.yaml")
+	outputPath := filepath.Join(dir, "out.json")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--tags", "pet", "--format", "json",
+		"--include-examples-from", examplesPath, "-o", outputPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Example map[string]any `json:"example"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, map[string]any{"id": float64(10), "name": "doggie"}, spec.Components.Schemas["Pet"].Example)
+}
+
+func TestOverlayFlag(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(`openapi: 3.0.3
+info:
+  title: Overlay
+  version: 1.0.0
+servers:
+  - url: https://prod.example.com
+paths: {}
+`), 0600))
+
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	outputPath := filepath.Join(dir, "out.json")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--overlay", overlayPath, "--format", "json", "-o", outputPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "https://prod.example.com")
+}
+
+func TestTableOfContentsFlag(t *testing.T) {
+	app := cmd.NewApp()
+
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	outputPath := filepath.Join(t.TempDir(), "toc.json")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--tags", "users", "--toc", "-o", outputPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	var toc []openax.TagSection
+	require.NoError(t, json.Unmarshal(data, &toc))
+	require.Len(t, toc, 1)
+	assert.Equal(t, "users", toc[0].Name)
+	assert.NotEmpty(t, toc[0].Operations)
+}
+
+func TestManifestFlag(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	specPath := filepath.Join("..", "testdata", "specs", "petstore.yaml")
+	manifestPath := filepath.Join(dir, "manifest.json")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--tags", "store", "--format", "json",
+		"-o", filepath.Join(dir, "out.json"), "--manifest", manifestPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+
+	var manifest openax.ComponentManifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, []string{"Order"}, manifest["schemas"])
+}
+
+func TestCSVFlag(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	specPath := filepath.Join("..", "testdata", "specs", "petstore.yaml")
+	csvPath := filepath.Join(dir, "endpoints.csv")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--tags", "store", "--format", "json",
+		"-o", filepath.Join(dir, "out.json"), "--csv", csvPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("method,path,operationId,tags,summary,deprecated\nGET,/store/inventory,getInventory,store,")))
+}
+
+func TestSideOutputsReuseLoadedInput(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("..", "testdata", "specs", "petstore.yaml"))
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write(spec)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "out.json")
+	manifestPath := filepath.Join(dir, "manifest.json")
+	app := cmd.NewApp()
+	err = app.Run(context.Background(), []string{
+		"openax", "-i", server.URL + "/openapi.yaml", "--tags", "store", "--format", "json",
+		"-o", outputPath, "--manifest", manifestPath, "--csv", filepath.Join(dir, "endpoints.csv"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load(), "The input should be fetched once for all outputs")
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	var manifest openax.ComponentManifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+
+	output, err := openax.New().LoadFromFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, openax.ListComponents(output), manifest, "The manifest should describe the written output")
+}
+
+func TestChangedSchemasFlag(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	basePath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	base, err := os.ReadFile(basePath)
+	require.NoError(t, err)
+
+	// Change the type of Post.title, the only "title" property in the spec
+	changed := bytes.Replace(base, []byte("title:\n          type: string"), []byte("title:\n          type: integer"), 1)
+	require.NotEqual(t, base, changed)
+	specPath := filepath.Join(dir, "current.yaml")
+	require.NoError(t, os.WriteFile(specPath, changed, 0600))
+
+	outPath := filepath.Join(dir, "out.json")
+	err = app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--changed-schemas", basePath, "--format", "json", "-o", outPath,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	var spec struct {
+		Paths      map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Empty(t, spec.Paths)
+	assert.Len(t, spec.Components.Schemas, 2)
+	assert.Contains(t, spec.Components.Schemas, "Post")
+	assert.Contains(t, spec.Components.Schemas, "User")
+}
+
+func TestPrintOptions(t *testing.T) {
+	app := cmd.NewApp()
+	var stderr bytes.Buffer
+	app.ErrWriter = &stderr
+
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--tags", "users", "--prune-components",
+		"--format", "json", "--print-options", "--dry-run",
+	})
+	require.NoError(t, err)
+
+	var printed struct {
+		Input  string               `json:"input"`
+		Filter openax.FilterOptions `json:"filter"`
+		Output struct {
+			Format string `json:"format"`
+			DryRun bool   `json:"dryRun"`
+		} `json:"output"`
+	}
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), &printed))
+	assert.Equal(t, specPath, printed.Input)
+	assert.Equal(t, []string{"users"}, printed.Filter.Tags)
+	assert.True(t, printed.Filter.PruneComponents)
+	assert.Equal(t, "json", printed.Output.Format)
+	assert.True(t, printed.Output.DryRun)
+}
+
+func TestConfigFlagPrecedence(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "openax.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tags: [users, admin]
+prune-components: true
+format: json
+`), 0600))
+
+	app := cmd.NewApp()
+	var stderr bytes.Buffer
+	app.ErrWriter = &stderr
+
+	specPath := filepath.Join("..", "testdata", "specs", "simple.yaml")
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", specPath, "--config", configPath, "--format", "yaml",
+		"--print-options", "--dry-run",
+	})
+	require.NoError(t, err)
+
+	var printed struct {
+		Config string               `json:"config"`
+		Filter openax.FilterOptions `json:"filter"`
+		Output struct {
+			Format string `json:"format"`
+		} `json:"output"`
+	}
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), &printed))
+	assert.Equal(t, configPath, printed.Config)
+	assert.Equal(t, []string{"users", "admin"}, printed.Filter.Tags, "Config values should apply")
+	assert.True(t, printed.Filter.PruneComponents)
+	assert.Equal(t, "yaml", printed.Output.Format, "Flags should override the config")
+
+	t.Run("unknown option", func(t *testing.T) {
+		badConfig := filepath.Join(t.TempDir(), "openax.json")
+		require.NoError(t, os.WriteFile(badConfig, []byte(`{"widgets": true}`), 0600))
+
+		err := cmd.NewApp().Run(context.Background(), []string{"openax", "-i", specPath, "--config", badConfig})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid option "widgets"`)
+	})
+}
+
+func TestValidateDirJUnitReport(t *testing.T) {
+	app := cmd.NewApp()
+
+	dir := t.TempDir()
+	spec, err := os.ReadFile(filepath.Join("..", "testdata", "specs", "simple.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "valid.yaml"), spec, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte(`openapi: 3.0.3
+paths: {}
+`), 0600))
+
+	reportPath := filepath.Join(t.TempDir(), "report.xml")
+	err = app.Run(context.Background(), []string{"openax", "--validate-only", "-i", dir, "--junit", reportPath})
+	require.Error(t, err, "An invalid spec should fail the run")
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+
+	var report struct {
+		Suites []struct {
+			Tests     int `xml:"tests,attr"`
+			Failures  int `xml:"failures,attr"`
+			TestCases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &report))
+
+	require.Len(t, report.Suites, 1)
+	suite := report.Suites[0]
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	require.Len(t, suite.TestCases, 2)
+
+	assert.Equal(t, filepath.Join(dir, "invalid.yaml"), suite.TestCases[0].Name)
+	require.NotNil(t, suite.TestCases[0].Failure)
+	assert.NotEmpty(t, suite.TestCases[0].Failure.Text)
+
+	assert.Equal(t, filepath.Join(dir, "valid.yaml"), suite.TestCases[1].Name)
+	assert.Nil(t, suite.TestCases[1].Failure)
+}
+
+func TestFilterDirConcurrency(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("..", "testdata", "specs", "simple.yaml"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), spec, 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "b.yml"), spec, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a spec"), 0600))
+
+	outputDir := t.TempDir()
+	app := cmd.NewApp()
+	err = app.Run(context.Background(), []string{
+		"openax", "-i", dir, "-o", outputDir, "-f", "json", "--recursive", "--concurrency", "2",
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"a.json", filepath.Join("nested", "b.json")} {
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err, "Each spec should be written under its relative path")
+
+		doc, err := openax.New().LoadFromData(data)
+		require.NoError(t, err)
+		assert.NotZero(t, doc.Paths.Len())
+	}
+
+	err = cmd.NewApp().Run(context.Background(), []string{"openax", "-i", dir, "--concurrency", "2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires --output")
+}
+
+func TestRetriesFlag(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("..", "testdata", "specs", "simple.yaml"))
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first fetch to simulate a flaky host
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(spec)
+	}))
+	defer server.Close()
+
+	app := cmd.NewApp()
+	err = app.Run(context.Background(), []string{
+		"openax", "-i", server.URL + "/openapi.yaml", "--retries", "2", "--dry-run",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestTimeoutFlag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	app := cmd.NewApp()
+	start := time.Now()
+	err := app.Run(context.Background(), []string{
+		"openax", "-i", server.URL + "/openapi.yaml", "--timeout", "50ms", "--dry-run",
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "timed out after 50ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,55 @@
+package loader
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Alias describes a YAML alias found in a specification.
+type Alias struct {
+	Name   string // Name of the anchor the alias refers to
+	Line   int    // Line number of the alias (1-based)
+	Column int    // Column number of the alias (1-based)
+}
+
+// AliasError indicates that a specification uses YAML aliases while
+// Options.RejectAliases is set.
+type AliasError struct {
+	Aliases []Alias
+}
+
+func (e AliasError) Error() string {
+	locations := make([]string, len(e.Aliases))
+	for i, alias := range e.Aliases {
+		locations[i] = fmt.Sprintf("*%s at line %d, col %d", alias.Name, alias.Line, alias.Column)
+	}
+	return fmt.Sprintf("YAML aliases are not allowed (%d found): %s", len(e.Aliases), strings.Join(locations, "; "))
+}
+
+// checkAliases returns an AliasError if the data contains YAML aliases.
+// Data that is not valid YAML is left for the OpenAPI loader to report.
+func checkAliases(data []byte) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+
+	aliases := findAliases(&root, nil)
+	if len(aliases) > 0 {
+		return AliasError{Aliases: aliases}
+	}
+	return nil
+}
+
+// findAliases walks a YAML node tree collecting alias nodes in document order
+func findAliases(node *yaml.Node, aliases []Alias) []Alias {
+	if node.Kind == yaml.AliasNode {
+		return append(aliases, Alias{Name: node.Value, Line: node.Line, Column: node.Column})
+	}
+	for _, child := range node.Content {
+		aliases = findAliases(child, aliases)
+	}
+	return aliases
+}
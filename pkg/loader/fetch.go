@@ -0,0 +1,99 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultRetryBackoff is the delay before the first retry when Options.RetryBackoff is unset
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// permanentError marks a fetch failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// NewReadFromURIFunc returns a URI reader for an openapi3.Loader that fetches remote
+// documents, including external $ref targets, with the loader's context and the
+// configured timeout and retries. Local files are read as usual, and every document
+// is fetched at most once per reader. Only Retries, RetryBackoff, and Timeout of the
+// options are used.
+func NewReadFromURIFunc(opts Options) openapi3.ReadFromURIFunc {
+	client := &http.Client{Timeout: opts.Timeout}
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	readFromHTTP := func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "" || location.Host == "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+
+		ctx := loader.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		delay := backoff
+		var lastErr error
+		for attempt := 0; attempt <= opts.Retries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(delay):
+				}
+				delay *= 2
+			}
+
+			data, err := fetch(ctx, client, location)
+			if err == nil {
+				return data, nil
+			}
+			lastErr = err
+
+			var permanent permanentError
+			if errors.As(err, &permanent) {
+				return nil, permanent.err
+			}
+		}
+		return nil, fmt.Errorf("error loading %q after %d attempt(s): %w", location.String(), opts.Retries+1, lastErr)
+	}
+
+	return openapi3.URIMapCache(openapi3.ReadFromURIs(readFromHTTP, openapi3.ReadFromFile))
+}
+
+// fetch performs a single GET request. Client errors other than 429 are permanent.
+func fetch(ctx context.Context, client *http.Client, location *url.URL) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, permanentError{err}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		err := fmt.Errorf("error loading %q: request returned status code %d", location.String(), resp.StatusCode)
+		if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, permanentError{err}
+		}
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -14,6 +14,8 @@
 //	loader := loader.NewWithOptions(loader.Options{
 //		AllowExternalRefs: true,
 //		Context:           ctx,
+//		Retries:           3,
+//		Timeout:           10 * time.Second,
 //	})
 //	doc, err := loader.LoadFromURL("https://api.example.com/spec.yaml")
 //
@@ -25,20 +27,39 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // Loader wraps the OpenAPI loader with additional functionality.
 type Loader struct {
-	loader *openapi3.Loader
+	loader        *openapi3.Loader
+	rejectAliases bool
 }
 
 // Options defines loading options.
 type Options struct {
 	AllowExternalRefs bool
 	Context           context.Context
+
+	// RejectAliases rejects specs containing YAML aliases with an AliasError.
+	// By default anchors and aliases are allowed and expanded in place.
+	RejectAliases bool
+
+	// Retries is the number of additional attempts made when fetching a remote
+	// document, including external $ref targets, fails with a network error or a
+	// 5xx/429 response. Zero disables retries.
+	Retries int
+
+	// RetryBackoff is the delay before the first retry; it doubles after every
+	// failed attempt. Defaults to 100ms when retries are enabled.
+	RetryBackoff time.Duration
+
+	// Timeout bounds each remote fetch. Zero means no timeout.
+	Timeout time.Duration
 }
 
 // New creates a new loader with default options.
@@ -56,16 +77,30 @@ func NewWithOptions(opts Options) *Loader {
 		ctx = context.Background()
 	}
 
-	return &Loader{
+	l := &Loader{
 		loader: &openapi3.Loader{
 			Context:               ctx,
 			IsExternalRefsAllowed: opts.AllowExternalRefs,
 		},
+		rejectAliases: opts.RejectAliases,
 	}
+	if opts.Retries > 0 || opts.Timeout > 0 {
+		l.loader.ReadFromURIFunc = NewReadFromURIFunc(opts)
+	}
+	return l
 }
 
 // LoadFromFile loads an OpenAPI specification from a local file.
 func (l *Loader) LoadFromFile(filePath string) (*openapi3.T, error) {
+	if l.rejectAliases {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAliases(data); err != nil {
+			return nil, err
+		}
+	}
 	return l.loader.LoadFromFile(filePath)
 }
 
@@ -75,11 +110,30 @@ func (l *Loader) LoadFromURL(urlStr string) (*openapi3.T, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
+	if l.rejectAliases {
+		read := l.loader.ReadFromURIFunc
+		if read == nil {
+			read = openapi3.DefaultReadFromURI
+		}
+		data, err := read(l.loader, u)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAliases(data); err != nil {
+			return nil, err
+		}
+		return l.loader.LoadFromDataWithPath(data, u)
+	}
 	return l.loader.LoadFromURI(u)
 }
 
 // LoadFromData loads an OpenAPI specification from raw data.
 func (l *Loader) LoadFromData(data []byte) (*openapi3.T, error) {
+	if l.rejectAliases {
+		if err := checkAliases(data); err != nil {
+			return nil, err
+		}
+	}
 	return l.loader.LoadFromData(data)
 }
 
@@ -2,8 +2,13 @@ package loader_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/imtanmoy/openax/pkg/loader"
 	"github.com/stretchr/testify/assert"
@@ -243,3 +248,138 @@ func TestLoadFromURL(t *testing.T) {
 }
 
 // Helper function to get absolute path to test data
+
+func TestRejectAliases(t *testing.T) {
+	anchoredYAML := []byte(`
+openapi: 3.0.3
+info:
+  title: Anchored API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200': &ok
+          description: OK
+  /owners:
+    get:
+      responses:
+        '200': *ok
+`)
+
+	t.Run("aliases expanded by default", func(t *testing.T) {
+		l := loader.New()
+
+		doc, err := l.LoadFromData(anchoredYAML)
+		require.NoError(t, err)
+		require.NotNil(t, doc.Paths.Value("/owners"))
+		assert.Equal(t, "OK", *doc.Paths.Value("/owners").Get.Responses.Value("200").Value.Description)
+	})
+
+	t.Run("aliases expanded with zero options", func(t *testing.T) {
+		l := loader.NewWithOptions(loader.Options{})
+
+		doc, err := l.LoadFromData(anchoredYAML)
+		require.NoError(t, err)
+		assert.Equal(t, "OK", *doc.Paths.Value("/owners").Get.Responses.Value("200").Value.Description)
+	})
+
+	t.Run("aliases rejected when enabled", func(t *testing.T) {
+		l := loader.NewWithOptions(loader.Options{
+			Context:       context.Background(),
+			RejectAliases: true,
+		})
+
+		doc, err := l.LoadFromData(anchoredYAML)
+		require.Error(t, err)
+		assert.Nil(t, doc)
+
+		var aliasErr loader.AliasError
+		require.ErrorAs(t, err, &aliasErr)
+		require.Len(t, aliasErr.Aliases, 1)
+		assert.Equal(t, "ok", aliasErr.Aliases[0].Name)
+		assert.Equal(t, 15, aliasErr.Aliases[0].Line)
+		assert.Contains(t, err.Error(), "*ok at line 15")
+	})
+
+	t.Run("spec without aliases loads when enabled", func(t *testing.T) {
+		l := loader.NewWithOptions(loader.Options{RejectAliases: true})
+
+		doc, err := l.LoadFromFile("../../testdata/specs/simple.yaml")
+		require.NoError(t, err)
+		assert.NotNil(t, doc)
+	})
+}
+
+func TestExternalRefRetries(t *testing.T) {
+	var commonRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.yaml":
+			fmt.Fprint(w, `openapi: 3.0.3
+info:
+  title: Remote API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'http://`+r.Host+`/common.yaml#/components/schemas/Pet'
+`)
+		case "/common.yaml":
+			// Fail the first two fetches to simulate a flaky host
+			if commonRequests.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("retries flaky external ref", func(t *testing.T) {
+		commonRequests.Store(0)
+		l := loader.NewWithOptions(loader.Options{
+			AllowExternalRefs: true,
+			Retries:           3,
+			RetryBackoff:      time.Millisecond,
+			Timeout:           5 * time.Second,
+		})
+
+		doc, err := l.LoadFromURL(server.URL + "/openapi.yaml")
+		require.NoError(t, err)
+
+		schema := doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Content.Get("application/json").Schema
+		require.NotNil(t, schema.Value)
+		assert.Contains(t, schema.Value.Properties, "name")
+		assert.Equal(t, int32(3), commonRequests.Load(), "Expected two failed attempts and one success")
+	})
+
+	t.Run("gives up after configured retries", func(t *testing.T) {
+		commonRequests.Store(0)
+		l := loader.NewWithOptions(loader.Options{
+			AllowExternalRefs: true,
+			Retries:           1,
+			RetryBackoff:      time.Millisecond,
+		})
+
+		_, err := l.LoadFromURL(server.URL + "/openapi.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 2 attempt(s)")
+		assert.Equal(t, int32(2), commonRequests.Load())
+	})
+}
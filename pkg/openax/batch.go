@@ -0,0 +1,101 @@
+package openax
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FilterAll filters several specifications with the same options in parallel.
+//
+// At most concurrency documents are filtered at once; zero or a negative value
+// uses runtime.NumCPU(). Results are returned in input order. If any document
+// fails, the first error in input order is returned.
+//
+// Example:
+//
+//	filtered, err := client.FilterAll(docs, openax.FilterOptions{
+//		Tags:            []string{"public"},
+//		PruneComponents: true,
+//	}, 4)
+func (c *Client) FilterAll(docs []*openapi3.T, opts FilterOptions, concurrency int) ([]*openapi3.T, error) {
+	results := make([]*openapi3.T, len(docs))
+	err := runBounded(len(docs), concurrency, func(i int) error {
+		filtered, err := applyFilter(docs[i], opts)
+		if err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+		results[i] = filtered
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FilterEach loads, filters, and handles n specifications in parallel, e.g. to
+// stream a directory of specifications to disk.
+//
+// At most concurrency documents are in flight at once; zero or a negative value
+// uses runtime.NumCPU(). load returns the i-th document and handle receives its
+// filtered result. A document is released once handle returns, so memory use is
+// bounded by concurrency rather than by n. load and handle are called from several
+// goroutines, and each load should use its own Client. If any document fails, the
+// first error in index order is returned.
+//
+// Example:
+//
+//	err := openax.FilterEach(len(files), opts, 4,
+//		func(i int) (*openapi3.T, error) { return openax.New().LoadFromFile(files[i]) },
+//		func(i int, filtered *openapi3.T) error { return write(files[i], filtered) })
+func FilterEach(n int, opts FilterOptions, concurrency int, load func(i int) (*openapi3.T, error), handle func(i int, filtered *openapi3.T) error) error {
+	return runBounded(n, concurrency, func(i int) error {
+		doc, err := load(i)
+		if err != nil {
+			return err
+		}
+		filtered, err := applyFilter(doc, opts)
+		if err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+		return handle(i, filtered)
+	})
+}
+
+// runBounded calls process for each index in [0, n) using at most concurrency
+// workers and returns the first error in index order
+func runBounded(n, concurrency int, process func(i int) error) error {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	concurrency = min(concurrency, n)
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = process(i)
+			}
+		}()
+	}
+
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
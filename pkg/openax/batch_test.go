@@ -0,0 +1,101 @@
+package openax
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBoundedRespectsConcurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	var mu sync.Mutex
+	processed := make(map[int]bool)
+
+	// Fake processor tracking how many calls run at once
+	err := runBounded(20, 3, func(i int) error {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		processed[i] = true
+		mu.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, processed, 20, "Every item should be processed")
+	assert.LessOrEqual(t, peak.Load(), int32(3), "No more than 3 items should be processed at once")
+	assert.Greater(t, peak.Load(), int32(1), "Items should be processed in parallel")
+}
+
+func TestRunBoundedReturnsFirstError(t *testing.T) {
+	err := runBounded(5, 0, func(i int) error {
+		if i >= 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "failed")
+}
+
+func TestFilterAll(t *testing.T) {
+	client := New()
+	docs := []*openapi3.T{createTestAPISpec(5, 2), createTestAPISpec(10, 2)}
+
+	filtered, err := client.FilterAll(docs, FilterOptions{Paths: []string{"/resource0"}}, 1)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	for _, doc := range filtered {
+		assert.NotNil(t, doc.Paths.Value("/resource0"))
+	}
+
+	_, err = client.FilterAll(docs, FilterOptions{Schemas: []string{"Unicorn"}}, 2)
+	assert.ErrorContains(t, err, "document 0")
+}
+
+func TestFilterEach(t *testing.T) {
+	var live, peak atomic.Int32
+	handled := make([]int, 8)
+
+	err := FilterEach(8, FilterOptions{Paths: []string{"/resource0"}}, 2,
+		func(i int) (*openapi3.T, error) {
+			current := live.Add(1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			return createTestAPISpec(i+1, 1), nil
+		},
+		func(i int, filtered *openapi3.T) error {
+			defer live.Add(-1)
+			time.Sleep(2 * time.Millisecond)
+			handled[i] = filtered.Paths.Len()
+			return nil
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1}, handled, "Every document should be handled")
+	assert.LessOrEqual(t, peak.Load(), int32(2), "No more than 2 documents should be live at once")
+
+	t.Run("load error", func(t *testing.T) {
+		err := FilterEach(3, FilterOptions{}, 0,
+			func(i int) (*openapi3.T, error) { return nil, errors.New("unreadable") },
+			func(int, *openapi3.T) error { return nil })
+		assert.EqualError(t, err, "unreadable")
+	})
+}
@@ -0,0 +1,275 @@
+package openax
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentNamePattern is the set of names the OpenAPI specification allows as
+// component keys
+var componentNamePattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+
+// BundleNaming derives the preferred component name for an external schema
+// reference. The reference is relative to the root document, e.g. "pet.yaml#/Pet".
+type BundleNaming func(ref string) string
+
+// FragmentNaming is the default BundleNaming. It names a schema after the last
+// segment of the reference's JSON pointer ("pet.yaml#/Pet" becomes "Pet"), or after
+// the file name without extension when the reference has no pointer
+// ("schemas/owner.yaml" becomes "owner").
+func FragmentNaming(ref string) string {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if fragment = strings.Trim(fragment, "/"); fragment != "" {
+		return fragment[strings.LastIndex(fragment, "/")+1:]
+	}
+	base := path.Base(file)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// Bundle moves the schemas a document references from external files into
+// components.schemas and rewrites every external schema reference to point at its
+// internal copy. The document must have been loaded with external references
+// allowed, so the referenced schemas are resolved.
+//
+// Names are chosen by naming (FragmentNaming if nil). References are named in the
+// order they are first reached walking the document: paths and methods in sorted
+// order, then the components by category and name. When a name is already taken,
+// by an existing component or an external schema reached earlier, a numeric suffix
+// is appended, so "pet.yaml#/Pet" becomes Pet and a colliding "order.yaml#/Pet"
+// reached after it becomes Pet2.
+//
+// The returned map records each original reference, relative to the root document,
+// and the internal reference that replaced it. The document is modified in place.
+//
+// Example:
+//
+//	mapping, err := openax.Bundle(doc, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(mapping["pet.yaml#/Pet"]) // #/components/schemas/Pet
+func Bundle(doc *openapi3.T, naming BundleNaming) (map[string]string, error) {
+	if naming == nil {
+		naming = FragmentNaming
+	}
+
+	b := &bundler{
+		refs:    make(map[string][]*openapi3.SchemaRef),
+		values:  make(map[string]*openapi3.Schema),
+		visited: make(map[*openapi3.Schema]bool),
+	}
+	b.document(doc)
+
+	mapping := make(map[string]string)
+	if len(b.refs) == 0 {
+		return mapping, nil
+	}
+
+	if doc.Components == nil {
+		doc.Components = &openapi3.Components{}
+	}
+	if doc.Components.Schemas == nil {
+		doc.Components.Schemas = make(openapi3.Schemas)
+	}
+
+	for _, ref := range b.order {
+		base := naming(ref)
+		if !componentNamePattern.MatchString(base) {
+			return nil, InvalidReferenceError{Ref: ref, Reason: fmt.Sprintf("cannot be bundled as component %q", base)}
+		}
+		if b.values[ref] == nil {
+			return nil, InvalidReferenceError{Ref: ref, Reason: "external reference is not resolved"}
+		}
+
+		name := base
+		for i := 2; doc.Components.Schemas[name] != nil; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		doc.Components.Schemas[name] = &openapi3.SchemaRef{Value: b.values[ref]}
+
+		internal := "#/components/schemas/" + name
+		for _, schema := range b.refs[ref] {
+			schema.Ref = internal
+		}
+		mapping[ref] = internal
+	}
+	return mapping, nil
+}
+
+// bundler collects the external schema references of a document in the order they
+// are first reached. Each object is walked with the file its relative references
+// resolve against; "" is the root.
+type bundler struct {
+	order   []string
+	refs    map[string][]*openapi3.SchemaRef
+	values  map[string]*openapi3.Schema
+	visited map[*openapi3.Schema]bool
+}
+
+// resolve returns the reference relative to the root document and the file that
+// nested references of its target resolve against. Local references of the root
+// document are not external.
+func (b *bundler) resolve(ref, file string) (string, string, bool) {
+	target, fragment, hasFragment := strings.Cut(ref, "#")
+	switch {
+	case target == "" && file == "":
+		return "", "", false
+	case target == "":
+		target = file
+	case file != "" && !strings.Contains(target, "://") && !path.IsAbs(target):
+		target = path.Join(path.Dir(file), target)
+	}
+
+	if hasFragment {
+		return target + "#" + fragment, target, true
+	}
+	return target, target, true
+}
+
+// nested returns the file nested references of a component resolve against
+func (b *bundler) nested(ref, file string) string {
+	if ref == "" {
+		return file
+	}
+	if _, target, ok := b.resolve(ref, file); ok {
+		return target
+	}
+	return file
+}
+
+func (b *bundler) document(doc *openapi3.T) {
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		b.parameters(pathItem.Parameters, "")
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			if operation := operations[method]; operation != nil {
+				b.operation(operation, "")
+			}
+		}
+	}
+
+	components := doc.Components
+	if components == nil {
+		return
+	}
+	for _, name := range sortedKeys(components.Schemas) {
+		b.schema(components.Schemas[name], "")
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		b.parameter(components.Parameters[name], "")
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		b.requestBody(components.RequestBodies[name], "")
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		b.response(components.Responses[name], "")
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		b.header(components.Headers[name], "")
+	}
+}
+
+func (b *bundler) operation(operation *openapi3.Operation, file string) {
+	b.parameters(operation.Parameters, file)
+	b.requestBody(operation.RequestBody, file)
+	if operation.Responses != nil {
+		responses := operation.Responses.Map()
+		for _, status := range sortedKeys(responses) {
+			b.response(responses[status], file)
+		}
+	}
+}
+
+func (b *bundler) parameters(params openapi3.Parameters, file string) {
+	for _, param := range params {
+		b.parameter(param, file)
+	}
+}
+
+func (b *bundler) parameter(param *openapi3.ParameterRef, file string) {
+	if param == nil || param.Value == nil {
+		return
+	}
+	file = b.nested(param.Ref, file)
+	b.schema(param.Value.Schema, file)
+	b.content(param.Value.Content, file)
+}
+
+func (b *bundler) requestBody(requestBody *openapi3.RequestBodyRef, file string) {
+	if requestBody == nil || requestBody.Value == nil {
+		return
+	}
+	b.content(requestBody.Value.Content, b.nested(requestBody.Ref, file))
+}
+
+func (b *bundler) response(response *openapi3.ResponseRef, file string) {
+	if response == nil || response.Value == nil {
+		return
+	}
+	file = b.nested(response.Ref, file)
+	b.content(response.Value.Content, file)
+	for _, name := range sortedKeys(response.Value.Headers) {
+		b.header(response.Value.Headers[name], file)
+	}
+}
+
+func (b *bundler) header(header *openapi3.HeaderRef, file string) {
+	if header == nil || header.Value == nil {
+		return
+	}
+	file = b.nested(header.Ref, file)
+	b.schema(header.Value.Schema, file)
+	b.content(header.Value.Content, file)
+}
+
+func (b *bundler) content(content openapi3.Content, file string) {
+	for _, contentType := range sortedKeys(content) {
+		if mediaType := content[contentType]; mediaType != nil {
+			b.schema(mediaType.Schema, file)
+		}
+	}
+}
+
+// schema records external schema references and walks every schema once, with the
+// file of the reference that led to it
+func (b *bundler) schema(schema *openapi3.SchemaRef, file string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if ref, target, ok := b.resolve(schema.Ref, file); ok {
+			if _, seen := b.refs[ref]; !seen {
+				b.order = append(b.order, ref)
+			}
+			b.refs[ref] = append(b.refs[ref], schema)
+			b.values[ref] = schema.Value
+			file = target
+		}
+	}
+
+	value := schema.Value
+	if value == nil || b.visited[value] {
+		return
+	}
+	b.visited[value] = true
+
+	for _, name := range sortedKeys(value.Properties) {
+		b.schema(value.Properties[name], file)
+	}
+	b.schema(value.Items, file)
+	b.schema(value.Not, file)
+	b.schema(value.AdditionalProperties.Schema, file)
+	for _, sub := range slices.Concat(value.AllOf, value.OneOf, value.AnyOf) {
+		b.schema(sub, file)
+	}
+}
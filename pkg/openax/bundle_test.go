@@ -0,0 +1,143 @@
+package openax_test
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"openapi.yaml": `openapi: 3.0.3
+info:
+  title: Bundled
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml#/Pet'
+  /store/orders:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/order.yaml#/Pet'
+components:
+  schemas:
+    Error:
+      type: string
+    Owner:
+      $ref: 'schemas/owner.yaml'
+`,
+		"schemas/pet.yaml": `Pet:
+  type: object
+  properties:
+    category:
+      $ref: '#/Category'
+Category:
+  type: string
+`,
+		"schemas/order.yaml": `Pet:
+  type: object
+  properties:
+    id:
+      type: integer
+    error:
+      $ref: 'errors.yaml#/Error'
+`,
+		"schemas/errors.yaml": `Error:
+  type: object
+`,
+		"schemas/owner.yaml": `type: object
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	client := openax.New()
+	doc, err := client.LoadFromFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err)
+
+	mapping, err := openax.Bundle(doc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"schemas/errors.yaml#/Error": "#/components/schemas/Error2",
+		"schemas/order.yaml#/Pet":    "#/components/schemas/Pet2",
+		"schemas/owner.yaml":         "#/components/schemas/owner",
+		"schemas/pet.yaml#/Category": "#/components/schemas/Category",
+		"schemas/pet.yaml#/Pet":      "#/components/schemas/Pet",
+	}, mapping)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var spec any
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Empty(t, collectRefs(spec, func(ref string) bool { return !strings.HasPrefix(ref, "#/components/schemas/") }))
+
+	assert.ElementsMatch(t, []string{"Category", "Error", "Error2", "Owner", "Pet", "Pet2", "owner"}, slices.Collect(maps.Keys(doc.Components.Schemas)))
+	assert.Equal(t, "#/components/schemas/Category", doc.Components.Schemas["Pet"].Value.Properties["category"].Ref)
+
+	bundled, err := client.LoadFromData(data)
+	require.NoError(t, err)
+	require.NoError(t, client.Validate(bundled))
+
+	t.Run("naming strategy", func(t *testing.T) {
+		doc, err := openax.New().LoadFromFile(filepath.Join(dir, "openapi.yaml"))
+		require.NoError(t, err)
+
+		mapping, err := openax.Bundle(doc, func(ref string) string {
+			return "Ext_" + openax.FragmentNaming(ref)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "#/components/schemas/Ext_Pet", mapping["schemas/pet.yaml#/Pet"])
+		assert.Equal(t, "#/components/schemas/Ext_Pet2", mapping["schemas/order.yaml#/Pet"])
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		doc, err := openax.New().LoadFromFile(filepath.Join(dir, "openapi.yaml"))
+		require.NoError(t, err)
+
+		_, err = openax.Bundle(doc, func(string) string { return "not/valid" })
+		var refErr openax.InvalidReferenceError
+		assert.ErrorAs(t, err, &refErr)
+	})
+}
+
+// collectRefs returns the $ref values of a decoded document accepted by match
+func collectRefs(node any, match func(string) bool) []string {
+	var refs []string
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok && match(ref) {
+			refs = append(refs, ref)
+		}
+		for _, child := range value {
+			refs = append(refs, collectRefs(child, match)...)
+		}
+	case []any:
+		for _, child := range value {
+			refs = append(refs, collectRefs(child, match)...)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,84 @@
+package openax
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackReferences(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Callback API
+  version: 1.0.0
+paths:
+  /subscriptions:
+    post:
+      operationId: subscribe
+      responses:
+        '201':
+          description: Subscribed
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}':
+            post:
+              requestBody:
+                $ref: '#/components/requestBodies/EventBody'
+              responses:
+                '200':
+                  $ref: '#/components/responses/Ack'
+  /unrelated:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Unrelated'
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        payload:
+          $ref: '#/components/schemas/Payload'
+    Payload:
+      type: object
+    AckBody:
+      type: object
+    Unrelated:
+      type: object
+  requestBodies:
+    EventBody:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Event'
+  responses:
+    Ack:
+      description: Acknowledged
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/AckBody'
+`))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	filteredDoc, err := applyFilter(doc, FilterOptions{
+		Operations:      []string{"subscribe"},
+		PruneComponents: true,
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, filteredDoc.Components.RequestBodies, "EventBody")
+	assert.Contains(t, filteredDoc.Components.Responses, "Ack")
+	assert.Contains(t, filteredDoc.Components.Schemas, "Event")
+	assert.Contains(t, filteredDoc.Components.Schemas, "Payload")
+	assert.Contains(t, filteredDoc.Components.Schemas, "AckBody")
+	assert.NotContains(t, filteredDoc.Components.Schemas, "Unrelated")
+}
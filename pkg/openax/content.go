@@ -0,0 +1,113 @@
+package openax
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// foldResponseContentTypes rewrites every retained response to offer only the
+// canonical content type. It returns the locations of the responses whose content
+// was dropped entirely because it could not be folded.
+func foldResponseContentTypes(filtered *openapi3.T, canonical string) ([]string, error) {
+	var emptied []string
+	fold := func(response *openapi3.ResponseRef, at string) (*openapi3.ResponseRef, error) {
+		folded, err := foldResponse(response, canonical)
+		if err != nil {
+			return nil, WrapError(err, "folding response content types", createLocation(at))
+		}
+		if folded != response && len(folded.Value.Content) == 0 {
+			emptied = append(emptied, at)
+		}
+		return folded, nil
+	}
+
+	for path, pathItem := range filtered.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		copied := *pathItem
+		for method, operation := range pathItem.Operations() {
+			if operation == nil || operation.Responses == nil {
+				continue
+			}
+			op := *operation
+			responses := &openapi3.Responses{Extensions: operation.Responses.Extensions}
+			for status, response := range operation.Responses.Map() {
+				folded, err := fold(response, operationLocation(path, method).Path+".responses."+status)
+				if err != nil {
+					return nil, err
+				}
+				responses.Set(status, folded)
+			}
+			op.Responses = responses
+			copied.SetOperation(method, &op)
+		}
+		filtered.Paths.Set(path, &copied)
+	}
+
+	if filtered.Components != nil {
+		for name, response := range filtered.Components.Responses {
+			folded, err := fold(response, "components.responses."+name)
+			if err != nil {
+				return nil, err
+			}
+			filtered.Components.Responses[name] = folded
+		}
+	}
+
+	return emptied, nil
+}
+
+// foldResponse returns the response with its content reduced to the canonical type.
+// When the canonical type is missing, the schema shared by all other media types is
+// reused for it; if their schemas differ, the content is dropped entirely.
+func foldResponse(response *openapi3.ResponseRef, canonical string) (*openapi3.ResponseRef, error) {
+	// Referenced components are folded where they are defined
+	if response == nil || response.Ref != "" || response.Value == nil || len(response.Value.Content) == 0 {
+		return response, nil
+	}
+	content := response.Value.Content
+
+	folded := openapi3.Content{}
+	for _, contentType := range sortedKeys(content) {
+		if mimeTypesMatch(contentType, canonical) {
+			folded[contentType] = content[contentType]
+		}
+	}
+
+	if len(folded) == 0 {
+		var schema *openapi3.SchemaRef
+		equivalent := true
+		for i, contentType := range sortedKeys(content) {
+			mediaType := content[contentType]
+			var candidate *openapi3.SchemaRef
+			if mediaType != nil {
+				candidate = mediaType.Schema
+			}
+			if i == 0 {
+				schema = candidate
+				continue
+			}
+			differs, err := jsonDiffers(schema, candidate)
+			if err != nil {
+				return nil, err
+			}
+			if differs {
+				equivalent = false
+				break
+			}
+		}
+		if equivalent && schema != nil {
+			folded[canonical] = &openapi3.MediaType{Schema: schema}
+		}
+	}
+
+	value := *response.Value
+	value.Content = folded
+	if len(folded) == 0 {
+		value.Content = nil
+	}
+
+	copied := *response
+	copied.Value = &value
+	return &copied, nil
+}
@@ -0,0 +1,107 @@
+package openax
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// deepCopySpec returns a copy of the document that shares no objects with it.
+// The document is copied structurally, so external references that were never
+// resolved are kept as they are, and objects shared within the document (e.g., a
+// schema reached through several references) are shared the same way in the copy.
+func deepCopySpec(doc *openapi3.T) *openapi3.T {
+	c := &structCopier{copies: make(map[copiedPointer]reflect.Value)}
+	return c.copy(reflect.ValueOf(doc)).Interface().(*openapi3.T)
+}
+
+// copiedPointer identifies a pointer already copied by structCopier
+type copiedPointer struct {
+	typ reflect.Type
+	ptr unsafe.Pointer
+}
+
+// structCopier deep-copies values, including the unexported fields kin-openapi uses
+// for its ordered maps, copying every pointer once so cycles are preserved
+type structCopier struct {
+	copies map[copiedPointer]reflect.Value
+}
+
+func (c *structCopier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := copiedPointer{typ: v.Type(), ptr: v.UnsafePointer()}
+		if copied, ok := c.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		c.copies[key] = copied
+		copied.Elem().Set(c.copy(v.Elem()))
+		return copied
+
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		if !v.CanAddr() {
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v = addressable
+		}
+		for i := range v.NumField() {
+			field := accessible(v.Field(i))
+			accessible(copied.Field(i)).Set(c.copy(field))
+		}
+		return copied
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			copied.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return copied
+
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			copied.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return copied
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			copied.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return copied
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(c.copy(v.Elem()))
+		return copied
+
+	default:
+		// Scalars are copied by value; functions and channels are shared
+		return v
+	}
+}
+
+// accessible returns an addressable struct field that can be read and set even
+// when it is unexported
+func accessible(field reflect.Value) reflect.Value {
+	if field.CanSet() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
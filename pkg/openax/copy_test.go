@@ -0,0 +1,58 @@
+package openax
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopySpec(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(`openapi: 3.0.3
+info:
+  title: External
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.yaml#/Pet'
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        next:
+          $ref: '#/components/schemas/Node'
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("Pet:\n  type: object\n"), 0600))
+
+	doc, err := New().LoadFromFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err)
+
+	copied := deepCopySpec(doc)
+
+	source := doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	schema := copied.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	assert.Equal(t, "pet.yaml#/Pet", schema.Ref, "External references should be kept")
+	require.NotNil(t, schema.Value)
+	assert.NotSame(t, source.Value, schema.Value)
+
+	// Cycles are preserved within the copy
+	node := copied.Components.Schemas["Node"].Value
+	assert.Same(t, node, node.Properties["next"].Value)
+	assert.NotSame(t, doc.Components.Schemas["Node"].Value, node)
+
+	schema.Value.Description = "changed"
+	copied.Paths.Value("/pets").Get.Summary = "changed"
+	assert.Empty(t, source.Value.Description)
+	assert.Empty(t, doc.Paths.Value("/pets").Get.Summary)
+}
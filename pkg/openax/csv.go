@@ -0,0 +1,68 @@
+package openax
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// endpointsCSVHeader lists the columns written by EndpointsCSV
+var endpointsCSVHeader = []string{"method", "path", "operationId", "tags", "summary", "deprecated"}
+
+// EndpointsCSV filters a specification and writes one CSV row per retained
+// operation, e.g. for spreadsheet-driven API reviews. The columns are method,
+// path, operationId, tags (separated by ";"), summary, and deprecated, preceded
+// by a header row. Rows are ordered by path and method.
+//
+// Example:
+//
+//	err := openax.EndpointsCSV(os.Stdout, doc, openax.FilterOptions{
+//		Tags: []string{"store"},
+//	})
+func EndpointsCSV(w io.Writer, doc *openapi3.T, opts FilterOptions) error {
+	filtered, err := applyFilter(doc, opts)
+	if err != nil {
+		return err
+	}
+	return WriteEndpointsCSV(w, filtered)
+}
+
+// WriteEndpointsCSV writes one CSV row per operation of a specification, in the
+// format of EndpointsCSV, without filtering it first.
+func WriteEndpointsCSV(w io.Writer, doc *openapi3.T) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(endpointsCSVHeader); err != nil {
+		return err
+	}
+
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil {
+				continue
+			}
+			err := writer.Write([]string{
+				strings.ToUpper(method),
+				path,
+				operation.OperationID,
+				strings.Join(operation.Tags, ";"),
+				operation.Summary,
+				strconv.FormatBool(operation.Deprecated),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
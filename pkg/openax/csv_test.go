@@ -0,0 +1,35 @@
+package openax_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsCSV(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	var buf bytes.Buffer
+	require.NoError(t, openax.EndpointsCSV(&buf, doc, openax.FilterOptions{Tags: []string{"store"}}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err, "Output should be valid CSV")
+	require.Len(t, records, 5, "Expected a header and one row per store operation")
+
+	assert.Equal(t, []string{"method", "path", "operationId", "tags", "summary", "deprecated"}, records[0])
+	assert.Equal(t, []string{"GET", "/store/inventory", "getInventory", "store", "Returns pet inventories by status.", "false"}, records[1])
+
+	t.Run("invalid options", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := openax.EndpointsCSV(&buf, doc, openax.FilterOptions{MaxPathsPerTag: -1})
+		assert.Error(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,195 @@
+package openax
+
+import (
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RemoveDeprecated returns a copy of the specification without its deprecated
+// elements: operations, path-level and operation parameters, and schema properties
+// marked `deprecated: true`. Paths left without operations are removed, and so are
+// the components that only the removed elements referenced. Components that were
+// already unused are kept, as are the objects that were not deprecated.
+//
+// The source document is not modified, and the result shares no objects with it.
+// RemoveDeprecated does not depend on filtering and works on any document.
+//
+// Example:
+//
+//	current, err := openax.RemoveDeprecated(doc)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func RemoveDeprecated(doc *openapi3.T) (*openapi3.T, error) {
+	result := deepCopySpec(doc)
+
+	before, err := collectDocumentUsage(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Paths != nil {
+		for _, path := range result.Paths.InMatchingOrder() {
+			pathItem := result.Paths.Value(path)
+			if pathItem == nil {
+				continue
+			}
+			hadOperations := len(pathItem.Operations()) > 0
+
+			pathItem.Parameters = withoutDeprecatedParameters(pathItem.Parameters)
+			for method, operation := range pathItem.Operations() {
+				if operation.Deprecated {
+					pathItem.SetOperation(method, nil)
+					continue
+				}
+				operation.Parameters = withoutDeprecatedParameters(operation.Parameters)
+			}
+
+			if hadOperations && len(pathItem.Operations()) == 0 {
+				result.Paths.Delete(path)
+			}
+		}
+	}
+
+	stripDeprecatedProperties(result)
+
+	after, err := collectDocumentUsage(result)
+	if err != nil {
+		return nil, err
+	}
+	removeOrphanedComponents(result.Components, before, after)
+
+	return result, nil
+}
+
+// withoutDeprecatedParameters returns the parameters that are not deprecated,
+// looking through references
+func withoutDeprecatedParameters(params openapi3.Parameters) openapi3.Parameters {
+	if params == nil {
+		return nil
+	}
+	return slices.DeleteFunc(params, func(param *openapi3.ParameterRef) bool {
+		return param != nil && param.Value != nil && param.Value.Deprecated
+	})
+}
+
+// stripDeprecatedProperties removes deprecated properties, and their required
+// entries, from every schema of the document
+func stripDeprecatedProperties(doc *openapi3.T) {
+	visited := make(map[*openapi3.Schema]bool)
+	var strip func(schema *openapi3.SchemaRef)
+	strip = func(schema *openapi3.SchemaRef) {
+		if schema == nil || schema.Value == nil || visited[schema.Value] {
+			return
+		}
+		value := schema.Value
+		visited[value] = true
+
+		for name, property := range value.Properties {
+			if property != nil && property.Value != nil && property.Value.Deprecated {
+				delete(value.Properties, name)
+				value.Required = slices.DeleteFunc(value.Required, func(required string) bool {
+					return required == name
+				})
+				continue
+			}
+			strip(property)
+		}
+		strip(value.Items)
+		strip(value.Not)
+		strip(value.AdditionalProperties.Schema)
+		for _, sub := range slices.Concat(value.AllOf, value.OneOf, value.AnyOf) {
+			strip(sub)
+		}
+	}
+	content := func(content openapi3.Content) {
+		for _, mediaType := range content {
+			if mediaType != nil {
+				strip(mediaType.Schema)
+			}
+		}
+	}
+	parameters := func(params openapi3.Parameters) {
+		for _, param := range params {
+			if param != nil && param.Value != nil {
+				strip(param.Value.Schema)
+				content(param.Value.Content)
+			}
+		}
+	}
+
+	if doc.Paths != nil {
+		for _, pathItem := range doc.Paths.Map() {
+			if pathItem == nil {
+				continue
+			}
+			parameters(pathItem.Parameters)
+			for _, operation := range pathItem.Operations() {
+				parameters(operation.Parameters)
+				if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+					content(operation.RequestBody.Value.Content)
+				}
+				if operation.Responses != nil {
+					for _, response := range operation.Responses.Map() {
+						if response != nil && response.Value != nil {
+							content(response.Value.Content)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if doc.Components == nil {
+		return
+	}
+	for _, schema := range doc.Components.Schemas {
+		strip(schema)
+	}
+	for _, param := range doc.Components.Parameters {
+		parameters(openapi3.Parameters{param})
+	}
+	for _, requestBody := range doc.Components.RequestBodies {
+		if requestBody != nil && requestBody.Value != nil {
+			content(requestBody.Value.Content)
+		}
+	}
+	for _, response := range doc.Components.Responses {
+		if response != nil && response.Value != nil {
+			content(response.Value.Content)
+		}
+	}
+}
+
+// removeOrphanedComponents deletes the components that were used before but no
+// longer are
+func removeOrphanedComponents(components *openapi3.Components, before, after *ComponentUsage) {
+	if components == nil {
+		return
+	}
+	orphaned := func(name string, before, after map[string]bool) bool {
+		return before[name] && !after[name]
+	}
+
+	for name := range components.Schemas {
+		if orphaned(name, before.Schemas, after.Schemas) {
+			delete(components.Schemas, name)
+		}
+	}
+	for name := range components.Parameters {
+		if orphaned(name, before.Parameters, after.Parameters) {
+			delete(components.Parameters, name)
+		}
+	}
+	for name := range components.RequestBodies {
+		if orphaned(name, before.RequestBodies, after.RequestBodies) {
+			delete(components.RequestBodies, name)
+		}
+	}
+	for name := range components.Responses {
+		if orphaned(name, before.Responses, after.Responses) {
+			delete(components.Responses, name)
+		}
+	}
+}
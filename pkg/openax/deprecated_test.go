@@ -0,0 +1,146 @@
+package openax_test
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveDeprecated(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Legacy API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Legacy'
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      deprecated: true
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        "201":
+          description: Created
+  /pets/legacy:
+    get:
+      deprecated: true
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    Legacy:
+      name: legacy
+      in: query
+      deprecated: true
+      schema:
+        $ref: '#/components/schemas/LegacyFilter'
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/NewPet'
+  schemas:
+    Pet:
+      type: object
+      required: [id, nickname]
+      properties:
+        id:
+          type: integer
+        nickname:
+          type: string
+          deprecated: true
+        owner:
+          $ref: '#/components/schemas/OldOwner'
+    OldOwner:
+      type: object
+      deprecated: true
+    NewPet:
+      type: object
+    LegacyFilter:
+      type: string
+    Standalone:
+      type: string
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	result, err := openax.RemoveDeprecated(doc)
+	require.NoError(t, err, "RemoveDeprecated should not fail")
+
+	assert.Equal(t, []string{"/pets"}, result.Paths.InMatchingOrder(), "Paths without operations should be removed")
+	pets := result.Paths.Value("/pets")
+	assert.NotNil(t, pets.Get)
+	assert.Nil(t, pets.Post, "Deprecated operations should be removed")
+	require.Len(t, pets.Get.Parameters, 1)
+	assert.Equal(t, "limit", pets.Get.Parameters[0].Value.Name)
+
+	pet := result.Components.Schemas["Pet"].Value
+	assert.ElementsMatch(t, []string{"id"}, slices.Collect(maps.Keys(pet.Properties)))
+	assert.Equal(t, []string{"id"}, pet.Required)
+
+	assert.ElementsMatch(t, []string{"Pet", "Standalone"}, slices.Collect(maps.Keys(result.Components.Schemas)),
+		"Only components orphaned by the removal should be cleaned up")
+	assert.Empty(t, result.Components.Parameters)
+	assert.Empty(t, result.Components.RequestBodies)
+	require.NoError(t, client.Validate(result))
+
+	assert.NotNil(t, doc.Paths.Value("/pets").Post, "Source document should not be modified")
+	assert.Contains(t, doc.Components.Schemas["Pet"].Value.Properties, "nickname")
+}
+
+func TestRemoveDeprecatedExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(`openapi: 3.0.3
+info:
+  title: External
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.yaml#/Pet'
+    delete:
+      deprecated: true
+      responses:
+        "204":
+          description: No Content
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("Pet:\n  type: object\n"), 0600))
+
+	doc, err := openax.New().LoadFromFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err, "Failed to load spec")
+
+	result, err := openax.RemoveDeprecated(doc)
+	require.NoError(t, err, "RemoveDeprecated should work on specs with external references")
+
+	pets := result.Paths.Value("/pets")
+	assert.Nil(t, pets.Delete)
+	assert.Equal(t, "pet.yaml#/Pet", pets.Get.Responses.Value("200").Value.Content["application/json"].Schema.Ref)
+}
@@ -0,0 +1,337 @@
+package openax
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ChangeKind describes how an operation differs between two specifications.
+type ChangeKind string
+
+// Supported change kinds.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// OperationChange describes a single operation that differs between two specifications.
+type OperationChange struct {
+	Path   string     // Path of the operation (e.g., "/users/{id}")
+	Method string     // Upper-case HTTP method (e.g., "GET")
+	Kind   ChangeKind // How the operation changed
+}
+
+// SpecDiff lists the differences between two specifications.
+type SpecDiff struct {
+	// Operations lists added, removed, and modified operations ordered by path and method.
+	Operations []OperationChange
+}
+
+// Diff compares two specifications operation by operation.
+//
+// An operation is modified when its own definition or the path-level parameters it
+// inherits differ, or when any component they reference (directly or transitively)
+// differs between the two documents.
+//
+// Example:
+//
+//	diff, err := openax.Diff(lastRelease, current)
+//	for _, change := range diff.Operations {
+//		fmt.Printf("%s %s %s\n", change.Kind, change.Method, change.Path)
+//	}
+func Diff(base, current *openapi3.T) (*SpecDiff, error) {
+	baseOps := indexOperations(base)
+	currentOps := indexOperations(current)
+
+	baseClosures := newClosureResolver(base)
+	currentClosures := newClosureResolver(current)
+
+	diff := &SpecDiff{}
+	for key, currentOp := range currentOps {
+		baseOp, ok := baseOps[key]
+		if !ok {
+			diff.Operations = append(diff.Operations, OperationChange{Path: key.Path, Method: key.Method, Kind: ChangeAdded})
+			continue
+		}
+
+		changed, err := operationChanged(baseOp, currentOp, baseClosures, currentClosures)
+		if err != nil {
+			return nil, WrapError(err, "comparing operations", operationLocation(key.Path, key.Method))
+		}
+		if changed {
+			diff.Operations = append(diff.Operations, OperationChange{Path: key.Path, Method: key.Method, Kind: ChangeModified})
+		}
+	}
+
+	for key := range baseOps {
+		if _, ok := currentOps[key]; !ok {
+			diff.Operations = append(diff.Operations, OperationChange{Path: key.Path, Method: key.Method, Kind: ChangeRemoved})
+		}
+	}
+
+	slices.SortFunc(diff.Operations, func(a, b OperationChange) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+
+	return diff, nil
+}
+
+// FilterChanged filters the current specification down to the operations that were
+// added or modified since the base specification, along with their components.
+//
+// The usual filter options are applied on top of the change selection, so the
+// result can be narrowed further by path, operation, or tag.
+//
+// Example:
+//
+//	delta, err := openax.FilterChanged(lastRelease, current, openax.FilterOptions{
+//		PruneComponents: true,
+//	})
+func FilterChanged(base, current *openapi3.T, opts FilterOptions) (*openapi3.T, error) {
+	diff, err := Diff(base, current)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[operationKey]bool)
+	for _, change := range diff.Operations {
+		if change.Kind != ChangeRemoved {
+			changed[operationKey{Path: change.Path, Method: change.Method}] = true
+		}
+	}
+
+	// Restrict the current document to the changed operations before filtering
+	trimmed := *current
+	trimmed.Paths = &openapi3.Paths{}
+	for path, pathItem := range current.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+
+		item := *pathItem
+		kept := false
+		for method := range pathItem.Operations() {
+			if changed[operationKey{Path: path, Method: method}] {
+				kept = true
+			} else {
+				item.SetOperation(method, nil)
+			}
+		}
+		if kept {
+			trimmed.Paths.Set(path, &item)
+		}
+	}
+
+	return applyFilter(&trimmed, opts)
+}
+
+// ChangedSchemas returns the names of the component schemas that were added to the
+// current specification or whose definition differs from the base specification,
+// in sorted order. Schemas are compared by their canonical JSON form, so a schema
+// only changes with its own definition, not with the schemas it references.
+//
+// Example:
+//
+//	for _, name := range openax.ChangedSchemas(lastRelease, current) {
+//		fmt.Println(name)
+//	}
+func ChangedSchemas(base, current *openapi3.T) []string {
+	if current == nil || current.Components == nil {
+		return nil
+	}
+	var baseSchemas openapi3.Schemas
+	if base != nil && base.Components != nil {
+		baseSchemas = base.Components.Schemas
+	}
+
+	var changed []string
+	for _, name := range sortedKeys(current.Components.Schemas) {
+		baseSchema, ok := baseSchemas[name]
+		if !ok {
+			changed = append(changed, name)
+			continue
+		}
+		// Schemas that cannot be serialized are reported as changed
+		if differs, err := jsonDiffers(baseSchema, current.Components.Schemas[name]); err != nil || differs {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// FilterChangedSchemas filters the current specification down to the component
+// schemas reported by ChangedSchemas and the schemas they reference. The result
+// has no operations, which makes it suitable for targeted code generation.
+//
+// Schemas named in opts.Keep are retained as well; the other selection filters
+// have no operations to match.
+//
+// Example:
+//
+//	changed, err := openax.FilterChangedSchemas(lastRelease, current, openax.FilterOptions{})
+func FilterChangedSchemas(base, current *openapi3.T, opts FilterOptions) (*openapi3.T, error) {
+	keep := maps.Clone(opts.Keep)
+	if keep == nil {
+		keep = make(map[string][]string)
+	}
+	keep[ComponentSchemas] = slices.Concat(keep[ComponentSchemas], ChangedSchemas(base, current))
+	opts.Keep = keep
+
+	// Drop every operation so that only the kept schemas are retained
+	trimmed := *current
+	trimmed.Paths = &openapi3.Paths{}
+	opts.KeepEmptyPaths = false
+
+	return applyFilter(&trimmed, opts)
+}
+
+// operationKey identifies an operation by path and method
+type operationKey struct {
+	Path   string
+	Method string
+}
+
+// pathOperation is an operation together with the path-level parameters it inherits
+type pathOperation struct {
+	Operation      *openapi3.Operation
+	PathParameters openapi3.Parameters
+}
+
+// indexOperations maps every operation in the document by path and method
+func indexOperations(doc *openapi3.T) map[operationKey]pathOperation {
+	index := make(map[operationKey]pathOperation)
+	if doc == nil || doc.Paths == nil {
+		return index
+	}
+	for path, pathItem := range doc.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		for method, operation := range pathItem.Operations() {
+			if operation != nil {
+				index[operationKey{Path: path, Method: method}] = pathOperation{Operation: operation, PathParameters: pathItem.Parameters}
+			}
+		}
+	}
+	return index
+}
+
+// closureResolver computes the component closure of operations within a document
+type closureResolver struct {
+	doc       *openapi3.T
+	mimeTypes []string
+	deps      *componentDependencies
+}
+
+func newClosureResolver(doc *openapi3.T) *closureResolver {
+	components := doc.Components
+	if components == nil {
+		components = &openapi3.Components{}
+	}
+	return &closureResolver{
+		doc:       doc,
+		mimeTypes: findAllMimeTypes(doc),
+		deps:      buildComponentDependencies(components),
+	}
+}
+
+// closure returns all components an operation and its path-level parameters
+// reference, directly or transitively
+func (r *closureResolver) closure(op pathOperation) (*ComponentUsage, error) {
+	usage := &ComponentUsage{
+		Schemas:       make(map[string]bool),
+		Parameters:    make(map[string]bool),
+		RequestBodies: make(map[string]bool),
+		Responses:     make(map[string]bool),
+	}
+	if r.doc.Components == nil {
+		return usage, nil
+	}
+
+	err := collectReferencesFromOperation(r.doc, op.Operation, r.mimeTypes,
+		usage.Schemas, usage.RequestBodies, usage.Parameters, usage.Responses)
+	if err != nil {
+		return nil, err
+	}
+	shared := &openapi3.Operation{Parameters: op.PathParameters}
+	if err := processOperationParameters(r.doc, shared, usage.Schemas, usage.Parameters); err != nil {
+		return nil, err
+	}
+	expandComponentUsage(r.deps, usage)
+	return usage, nil
+}
+
+// operationChanged reports whether an operation, its path-level parameters, or any
+// component in their closure differs
+func operationChanged(baseOp, currentOp pathOperation, baseClosures, currentClosures *closureResolver) (bool, error) {
+	if changed, err := jsonDiffers(baseOp.Operation, currentOp.Operation); err != nil || changed {
+		return changed, err
+	}
+	if changed, err := jsonDiffers(baseOp.PathParameters, currentOp.PathParameters); err != nil || changed {
+		return changed, err
+	}
+
+	baseUsage, err := baseClosures.closure(baseOp)
+	if err != nil {
+		return false, err
+	}
+	currentUsage, err := currentClosures.closure(currentOp)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(baseUsage, currentUsage) {
+		return true, nil
+	}
+
+	baseComponents := baseClosures.doc.Components
+	currentComponents := currentClosures.doc.Components
+	if baseComponents == nil || currentComponents == nil {
+		return baseComponents != currentComponents, nil
+	}
+
+	for name := range currentUsage.Schemas {
+		if changed, err := jsonDiffers(baseComponents.Schemas[name], currentComponents.Schemas[name]); err != nil || changed {
+			return changed, err
+		}
+	}
+	for name := range currentUsage.Parameters {
+		if changed, err := jsonDiffers(baseComponents.Parameters[name], currentComponents.Parameters[name]); err != nil || changed {
+			return changed, err
+		}
+	}
+	for name := range currentUsage.RequestBodies {
+		if changed, err := jsonDiffers(baseComponents.RequestBodies[name], currentComponents.RequestBodies[name]); err != nil || changed {
+			return changed, err
+		}
+	}
+	for name := range currentUsage.Responses {
+		if changed, err := jsonDiffers(baseComponents.Responses[name], currentComponents.Responses[name]); err != nil || changed {
+			return changed, err
+		}
+	}
+
+	return false, nil
+}
+
+// jsonDiffers compares two values by their JSON serialization
+func jsonDiffers(a, b any) (bool, error) {
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize: %w", err)
+	}
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize: %w", err)
+	}
+	return string(aData) != string(bData), nil
+}
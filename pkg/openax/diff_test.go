@@ -0,0 +1,154 @@
+package openax_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	client := openax.New()
+
+	base, err := client.LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load base spec")
+	// A separate client avoids the loader returning the cached base document
+	current, err := openax.New().LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load current spec")
+
+	t.Run("identical specs", func(t *testing.T) {
+		diff, err := openax.Diff(base, current)
+		require.NoError(t, err)
+		assert.Empty(t, diff.Operations)
+	})
+
+	t.Run("schema change marks referencing operations", func(t *testing.T) {
+		changed, err := openax.New().LoadFromFile("../../testdata/specs/simple.yaml")
+		require.NoError(t, err)
+		changed.Components.Schemas["CreateUser"].Value.Description = "changed"
+
+		diff, err := openax.Diff(base, changed)
+		require.NoError(t, err)
+		assert.Equal(t, []openax.OperationChange{
+			{Path: "/users", Method: "POST", Kind: openax.ChangeModified},
+		}, diff.Operations)
+	})
+
+	t.Run("path-level parameter change marks operations under the path", func(t *testing.T) {
+		spec := []byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+      - name: verbose
+        in: query
+        schema:
+          type: boolean
+    get:
+      responses:
+        "200":
+          description: OK
+    delete:
+      responses:
+        "204":
+          description: No Content
+  /owners:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    PetID:
+      name: id
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/PetIDValue'
+  schemas:
+    PetIDValue:
+      type: string
+`)
+		pathBase, err := openax.New().LoadFromData(spec)
+		require.NoError(t, err)
+		expected := []openax.OperationChange{
+			{Path: "/pets/{id}", Method: "DELETE", Kind: openax.ChangeModified},
+			{Path: "/pets/{id}", Method: "GET", Kind: openax.ChangeModified},
+		}
+
+		inline, err := openax.New().LoadFromData(spec)
+		require.NoError(t, err)
+		inline.Paths.Value("/pets/{id}").Parameters[1].Value.Description = "changed"
+
+		diff, err := openax.Diff(pathBase, inline)
+		require.NoError(t, err)
+		assert.Equal(t, expected, diff.Operations)
+
+		referenced, err := openax.New().LoadFromData(spec)
+		require.NoError(t, err)
+		referenced.Components.Schemas["PetIDValue"].Value.Format = "uuid"
+
+		diff, err = openax.Diff(pathBase, referenced)
+		require.NoError(t, err)
+		assert.Equal(t, expected, diff.Operations, "A change to a component the path-level parameter references should count")
+
+		filtered, err := openax.FilterChanged(pathBase, referenced, openax.FilterOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/pets/{id}"}, filtered.Paths.InMatchingOrder())
+	})
+}
+
+func TestFilterChanged(t *testing.T) {
+	client := openax.New()
+
+	base, err := client.LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load base spec")
+	// A separate client avoids the loader returning the cached base document
+	current, err := openax.New().LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load current spec")
+
+	current.Paths.Value("/users").Get.Summary = "List all users"
+
+	filtered, err := openax.FilterChanged(base, current, openax.FilterOptions{PruneComponents: true})
+	require.NoError(t, err, "FilterChanged should not fail")
+
+	require.Equal(t, 1, filtered.Paths.Len(), "Only the changed path should be retained")
+	users := filtered.Paths.Value("/users")
+	require.NotNil(t, users)
+	assert.NotNil(t, users.Get, "Changed operation should be retained")
+	assert.Nil(t, users.Post, "Unchanged operation should be dropped")
+	assert.Equal(t, "List all users", users.Get.Summary)
+
+	assert.Contains(t, filtered.Components.Schemas, "User")
+	assert.NotContains(t, filtered.Components.Schemas, "CreateUser")
+	assert.NotContains(t, filtered.Components.Schemas, "Post")
+
+	// The current document must not be modified
+	assert.NotNil(t, current.Paths.Value("/users").Post)
+}
+
+func TestChangedSchemas(t *testing.T) {
+	base, err := openax.New().LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load base spec")
+	current, err := openax.New().LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load current spec")
+
+	assert.Empty(t, openax.ChangedSchemas(base, current))
+
+	current.Components.Schemas["Post"].Value.Properties["title"].Value.Type = &openapi3.Types{"integer"}
+	assert.Equal(t, []string{"Post"}, openax.ChangedSchemas(base, current))
+
+	filtered, err := openax.FilterChangedSchemas(base, current, openax.FilterOptions{})
+	require.NoError(t, err)
+
+	assert.Zero(t, filtered.Paths.Len(), "No operations should be retained")
+	assert.ElementsMatch(t, []string{"Post", "User"}, slices.Collect(maps.Keys(filtered.Components.Schemas)),
+		"The changed schema and its dependencies should be retained")
+}
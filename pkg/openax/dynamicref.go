@@ -0,0 +1,203 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSON Schema keywords for dynamic references. kin-openapi does not model them, so
+// they are decoded into the Extensions map of the schema declaring them.
+const (
+	dynamicRefKey    = "$dynamicRef"
+	recursiveRefKey  = "$recursiveRef"
+	dynamicAnchorKey = "$dynamicAnchor"
+)
+
+// dynamicRef is a $dynamicRef or $recursiveRef found in a retained schema
+type dynamicRef struct {
+	keyword string
+	ref     string
+	at      string
+}
+
+// resolveDynamicRefs pulls in the component schemas targeted by the $dynamicRef and
+// $recursiveRef keywords of retained schemas, which the regular reference extraction
+// does not see. A $dynamicRef to an anchor includes every component declaring a
+// matching $dynamicAnchor, since any of them may be the target at runtime. References
+// that cannot be resolved statically are reported as warnings because the filtered
+// spec may be missing their targets.
+func resolveDynamicRefs(doc *openapi3.T, filtered *openapi3.T, processedRefs *ProcessedRefs, report *FilterReport) error {
+	anchors := findDynamicAnchors(doc)
+
+	for {
+		added := false
+		var unresolved []dynamicRef
+		for _, ref := range findDynamicRefs(filtered) {
+			targets := dynamicRefTargets(doc, ref, anchors)
+			if len(targets) == 0 {
+				unresolved = append(unresolved, ref)
+				continue
+			}
+			for _, name := range targets {
+				if processedRefs.Schemas[name] {
+					continue
+				}
+				processedRefs.Schemas[name] = true
+				if err := resolveSchemaRefsRecursively(doc, filtered, name, make(map[string]bool), ref.at); err != nil {
+					return err
+				}
+				added = true
+			}
+		}
+
+		// Newly retained schemas may declare dynamic references of their own
+		if added {
+			continue
+		}
+		for _, ref := range unresolved {
+			report.warn(createLocation(ref.at), "%s %q cannot be resolved; the filtered spec may be missing its target", ref.keyword, ref.ref)
+		}
+		return nil
+	}
+}
+
+// dynamicRefTargets returns the names of the component schemas a dynamic reference
+// may resolve to, or nil if it cannot be resolved statically
+func dynamicRefTargets(doc *openapi3.T, ref dynamicRef, anchors map[string][]string) []string {
+	if name, ok := strings.CutPrefix(ref.ref, "#/components/schemas/"); ok {
+		if doc.Components != nil && doc.Components.Schemas[name] != nil {
+			return []string{name}
+		}
+		return nil
+	}
+	if anchor, ok := strings.CutPrefix(ref.ref, "#"); ok && ref.keyword == dynamicRefKey && anchor != "" && !strings.Contains(anchor, "/") {
+		return anchors[anchor]
+	}
+	return nil
+}
+
+// findDynamicAnchors maps every $dynamicAnchor declared in a component schema, at
+// any depth, to the names of the components declaring it
+func findDynamicAnchors(doc *openapi3.T) map[string][]string {
+	anchors := make(map[string][]string)
+	if doc.Components == nil {
+		return anchors
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		walkInlineSchemas(doc.Components.Schemas[name], name, func(schema *openapi3.Schema, _ string) {
+			anchor, ok := schema.Extensions[dynamicAnchorKey].(string)
+			if ok && (len(anchors[anchor]) == 0 || anchors[anchor][len(anchors[anchor])-1] != name) {
+				anchors[anchor] = append(anchors[anchor], name)
+			}
+		})
+	}
+	return anchors
+}
+
+// findDynamicRefs lists the dynamic references of the schemas in the filtered spec
+func findDynamicRefs(filtered *openapi3.T) []dynamicRef {
+	var refs []dynamicRef
+	visit := func(schema *openapi3.Schema, at string) {
+		for _, keyword := range []string{dynamicRefKey, recursiveRefKey} {
+			if ref, ok := schema.Extensions[keyword].(string); ok {
+				refs = append(refs, dynamicRef{keyword: keyword, ref: ref, at: at})
+			}
+		}
+	}
+
+	content := func(content openapi3.Content, at string) {
+		for _, mimeType := range sortedKeys(content) {
+			if mediaType := content[mimeType]; mediaType != nil {
+				walkInlineSchemas(mediaType.Schema, fmt.Sprintf("%s.content.%s.schema", at, mimeType), visit)
+			}
+		}
+	}
+	parameters := func(params openapi3.Parameters, at string) {
+		for i, param := range params {
+			if param != nil && param.Ref == "" && param.Value != nil {
+				walkInlineSchemas(param.Value.Schema, fmt.Sprintf("%s.parameters.%d.schema", at, i), visit)
+				content(param.Value.Content, fmt.Sprintf("%s.parameters.%d", at, i))
+			}
+		}
+	}
+
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		pathItem := filtered.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		parameters(pathItem.Parameters, "paths."+path)
+
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil {
+				continue
+			}
+			at := fmt.Sprintf("paths.%s.%s", path, strings.ToLower(method))
+			parameters(operation.Parameters, at)
+			if requestBody := operation.RequestBody; requestBody != nil && requestBody.Ref == "" && requestBody.Value != nil {
+				content(requestBody.Value.Content, at+".requestBody")
+			}
+			if operation.Responses != nil {
+				responses := operation.Responses.Map()
+				for _, status := range sortedKeys(responses) {
+					if response := responses[status]; response != nil && response.Ref == "" && response.Value != nil {
+						content(response.Value.Content, fmt.Sprintf("%s.responses.%s", at, status))
+					}
+				}
+			}
+		}
+	}
+
+	components := filtered.Components
+	for _, name := range sortedKeys(components.Schemas) {
+		walkInlineSchemas(components.Schemas[name], "components.schemas."+name, visit)
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		if param := components.Parameters[name]; param != nil && param.Value != nil {
+			walkInlineSchemas(param.Value.Schema, fmt.Sprintf("components.parameters.%s.schema", name), visit)
+			content(param.Value.Content, "components.parameters."+name)
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if requestBody := components.RequestBodies[name]; requestBody != nil && requestBody.Value != nil {
+			content(requestBody.Value.Content, "components.requestBodies."+name)
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if response := components.Responses[name]; response != nil && response.Value != nil {
+			content(response.Value.Content, "components.responses."+name)
+		}
+	}
+	return refs
+}
+
+// walkInlineSchemas calls visit for a schema and every subschema defined inline in
+// it. Referenced schemas are not entered; they are walked where they are defined.
+func walkInlineSchemas(schema *openapi3.SchemaRef, at string, visit func(schema *openapi3.Schema, at string)) {
+	if schema == nil || schema.Ref != "" || schema.Value == nil {
+		return
+	}
+	value := schema.Value
+	visit(value, at)
+
+	for _, name := range sortedKeys(value.Properties) {
+		walkInlineSchemas(value.Properties[name], at+".properties."+name, visit)
+	}
+	walkInlineSchemas(value.Items, at+".items", visit)
+	walkInlineSchemas(value.Not, at+".not", visit)
+	walkInlineSchemas(value.AdditionalProperties.Schema, at+".additionalProperties", visit)
+	for i, sub := range value.AllOf {
+		walkInlineSchemas(sub, fmt.Sprintf("%s.allOf.%d", at, i), visit)
+	}
+	for i, sub := range value.OneOf {
+		walkInlineSchemas(sub, fmt.Sprintf("%s.oneOf.%d", at, i), visit)
+	}
+	for i, sub := range value.AnyOf {
+		walkInlineSchemas(sub, fmt.Sprintf("%s.anyOf.%d", at, i), visit)
+	}
+}
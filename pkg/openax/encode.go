@@ -0,0 +1,74 @@
+package openax
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// supportedFormats lists the output formats accepted by Encode.
+var supportedFormats = []string{"json", "yaml", "yml"}
+
+// Encode serializes an OpenAPI specification in the given format.
+//
+// Supported formats are "json" (indented) and "yaml" (or "yml"), matched
+// case-insensitively. Unknown formats return an UnsupportedFormatError.
+//
+// Example:
+//
+//	data, err := openax.Encode(filtered, "json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func Encode(doc *openapi3.T, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(doc, "", "  ")
+	case "yaml", "yml":
+		return yaml.Marshal(doc)
+	default:
+		return nil, UnsupportedFormatError{Format: format, Supported: supportedFormats}
+	}
+}
+
+// Hash returns a deterministic SHA-256 hash of an OpenAPI specification, suitable
+// for use as a cache key or ETag.
+//
+// The document is serialized to canonical JSON (object keys sorted at every level,
+// no insignificant whitespace), so the hash does not depend on map iteration order.
+//
+// Example:
+//
+//	etag, err := openax.Hash(filtered)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	w.Header().Set("ETag", `"`+etag+`"`)
+func Hash(doc *openapi3.T) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize spec: %w", err)
+	}
+
+	// Round-trip through generic values so custom marshalers cannot affect key order.
+	// Numbers are kept verbatim, since float64 would merge integers above 2^53.
+	var canonical any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&canonical); err != nil {
+		return "", fmt.Errorf("failed to canonicalize spec: %w", err)
+	}
+	data, err = json.Marshal(canonical)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize spec: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -120,6 +120,16 @@ func (e FilterError) Unwrap() error {
 	return e.Cause
 }
 
+// UnsupportedFormatError indicates that an unknown output format was requested.
+type UnsupportedFormatError struct {
+	Format    string   // The format that was requested
+	Supported []string // The formats that are accepted
+}
+
+func (e UnsupportedFormatError) Error() string {
+	return fmt.Sprintf("unsupported output format: %s (supported: %s)", e.Format, strings.Join(e.Supported, ", "))
+}
+
 // WrapError wraps an error with additional context and location information.
 func WrapError(err error, operation string, location *SourceLocation) error {
 	if err == nil {
@@ -0,0 +1,48 @@
+package openax
+
+import (
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// InjectExamples attaches examples to component schemas by schema name.
+//
+// Each example is set as the `example` of the matching entry in
+// components.schemas, replacing any existing example. Schemas are copied before
+// being modified, so documents sharing them (such as the source of a filtered
+// spec) are left intact. The names of examples without a matching schema are
+// returned in sorted order so callers can report them.
+//
+// Example:
+//
+//	unknown := openax.InjectExamples(filtered, map[string]any{
+//		"Pet": map[string]any{"id": 1, "name": "Rex"},
+//	})
+//	for _, name := range unknown {
+//		log.Printf("no schema named %s", name)
+//	}
+func InjectExamples(doc *openapi3.T, examples map[string]any) []string {
+	var unknown []string
+
+	for name, example := range examples {
+		var schema *openapi3.SchemaRef
+		if doc.Components != nil {
+			schema = doc.Components.Schemas[name]
+		}
+		if schema == nil || schema.Value == nil {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		value := *schema.Value
+		value.Example = example
+
+		copied := *schema
+		copied.Value = &value
+		doc.Components.Schemas[name] = &copied
+	}
+
+	slices.Sort(unknown)
+	return unknown
+}
@@ -0,0 +1,32 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectExamples(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pet"}})
+	require.NoError(t, err, "Filter should not fail")
+
+	petExample := map[string]any{"id": 10, "name": "doggie"}
+	unknown := openax.InjectExamples(filtered, map[string]any{
+		"Pet":     petExample,
+		"Missing": "nothing",
+	})
+
+	assert.Equal(t, []string{"Missing"}, unknown)
+	require.Contains(t, filtered.Components.Schemas, "Pet")
+	assert.Equal(t, petExample, filtered.Components.Schemas["Pet"].Value.Example)
+
+	// The source document must not be modified
+	assert.Nil(t, doc.Components.Schemas["Pet"].Value.Example)
+}
@@ -0,0 +1,210 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// keptByFilterOptions is reported for retained components that nothing references,
+// such as components selected by Schemas or Keep
+const keptByFilterOptions = "filter options"
+
+// ExplainPrune reports why each component survived pruning.
+//
+// The result maps every retained schema, parameter, request body, and response
+// (keyed as "schemas.Category", "responses.NotFound", ...) to the sorted list of
+// places in the filtered specification that reference it. Operations are named
+// by method and path ("GET /pets responses.200.content.application/json.schema"),
+// components by their name and location within it ("Pet.properties.category").
+// Components retained without any referencer are explained by "filter options".
+//
+// Pruning is always enabled for the explanation. Nil is returned if filtering fails.
+//
+// Example:
+//
+//	reasons := client.ExplainPrune(doc, openax.FilterOptions{Tags: []string{"pets"}})
+//	fmt.Println(reasons["schemas.Category"]) // [Pet.properties.category]
+func (c *Client) ExplainPrune(doc *openapi3.T, opts FilterOptions) map[string][]string {
+	opts.PruneComponents = true
+	filtered, err := applyFilter(doc, opts)
+	if err != nil {
+		return nil
+	}
+
+	e := pruneExplainer{reasons: make(map[string][]string)}
+	e.document(filtered)
+	return e.result(filtered.Components)
+}
+
+// pruneExplainer records, for each referenced component, where it is referenced from
+type pruneExplainer struct {
+	reasons map[string][]string
+}
+
+// record notes that ref is referenced from the given location
+func (e pruneExplainer) record(ref, from string) {
+	name, ok := strings.CutPrefix(ref, "#/components/")
+	if !ok {
+		return
+	}
+	key := strings.ReplaceAll(name, "/", ".")
+	e.reasons[key] = append(e.reasons[key], from)
+}
+
+func (e pruneExplainer) document(filtered *openapi3.T) {
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		pathItem := filtered.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		e.parameters(pathItem.Parameters, path+" parameters")
+		for method, operation := range pathItem.Operations() {
+			if operation != nil {
+				e.operation(operation, fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+			}
+		}
+	}
+
+	components := filtered.Components
+	if components == nil {
+		return
+	}
+	for name, schema := range components.Schemas {
+		e.schema(schema, name)
+	}
+	for name, param := range components.Parameters {
+		e.parameter(param, "parameters."+name)
+	}
+	for name, requestBody := range components.RequestBodies {
+		e.requestBody(requestBody, "requestBodies."+name)
+	}
+	for name, response := range components.Responses {
+		e.response(response, "responses."+name)
+	}
+}
+
+func (e pruneExplainer) operation(operation *openapi3.Operation, at string) {
+	e.parameters(operation.Parameters, at+" parameters")
+	e.requestBody(operation.RequestBody, at+" requestBody")
+	for status, response := range operation.Responses.Map() {
+		e.response(response, fmt.Sprintf("%s responses.%s", at, status))
+	}
+}
+
+func (e pruneExplainer) parameters(params openapi3.Parameters, at string) {
+	for i, param := range params {
+		e.parameter(param, fmt.Sprintf("%s.%d", at, i))
+	}
+}
+
+func (e pruneExplainer) parameter(param *openapi3.ParameterRef, at string) {
+	if param == nil {
+		return
+	}
+	if param.Ref != "" {
+		e.record(param.Ref, at)
+		return
+	}
+	if param.Value != nil {
+		e.schema(param.Value.Schema, at+".schema")
+		e.content(param.Value.Content, at)
+	}
+}
+
+func (e pruneExplainer) requestBody(requestBody *openapi3.RequestBodyRef, at string) {
+	if requestBody == nil {
+		return
+	}
+	if requestBody.Ref != "" {
+		e.record(requestBody.Ref, at)
+		return
+	}
+	if requestBody.Value != nil {
+		e.content(requestBody.Value.Content, at)
+	}
+}
+
+func (e pruneExplainer) response(response *openapi3.ResponseRef, at string) {
+	if response == nil {
+		return
+	}
+	if response.Ref != "" {
+		e.record(response.Ref, at)
+		return
+	}
+	if response.Value != nil {
+		e.content(response.Value.Content, at)
+	}
+}
+
+func (e pruneExplainer) content(content openapi3.Content, at string) {
+	for mimeType, mediaType := range content {
+		if mediaType != nil {
+			e.schema(mediaType.Schema, fmt.Sprintf("%s.content.%s.schema", at, mimeType))
+		}
+	}
+}
+
+// schema records the references of an inline schema; referenced components are
+// explained where they are defined
+func (e pruneExplainer) schema(schema *openapi3.SchemaRef, at string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		e.record(schema.Ref, at)
+		return
+	}
+	value := schema.Value
+	if value == nil {
+		return
+	}
+
+	for name, property := range value.Properties {
+		e.schema(property, at+".properties."+name)
+	}
+	e.schema(value.Items, at+".items")
+	e.schema(value.Not, at+".not")
+	e.schema(value.AdditionalProperties.Schema, at+".additionalProperties")
+	for i, sub := range value.AllOf {
+		e.schema(sub, fmt.Sprintf("%s.allOf.%d", at, i))
+	}
+	for i, sub := range value.OneOf {
+		e.schema(sub, fmt.Sprintf("%s.oneOf.%d", at, i))
+	}
+	for i, sub := range value.AnyOf {
+		e.schema(sub, fmt.Sprintf("%s.anyOf.%d", at, i))
+	}
+}
+
+// result returns the sorted referencers of every retained component
+func (e pruneExplainer) result(components *openapi3.Components) map[string][]string {
+	result := make(map[string][]string)
+	if components == nil {
+		return result
+	}
+
+	add := func(key string) {
+		reasons := slices.Compact(slices.Sorted(slices.Values(e.reasons[key])))
+		if len(reasons) == 0 {
+			reasons = []string{keptByFilterOptions}
+		}
+		result[key] = reasons
+	}
+	for name := range components.Schemas {
+		add("schemas." + name)
+	}
+	for name := range components.Parameters {
+		add("parameters." + name)
+	}
+	for name := range components.RequestBodies {
+		add("requestBodies." + name)
+	}
+	for name := range components.Responses {
+		add("responses." + name)
+	}
+	return result
+}
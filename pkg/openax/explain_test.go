@@ -0,0 +1,39 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainPrune(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("schema kept by another schema", func(t *testing.T) {
+		reasons := client.ExplainPrune(doc, openax.FilterOptions{Paths: []string{"/pet/{petId}"}})
+		require.NotNil(t, reasons)
+
+		assert.Equal(t, []string{"Pet.properties.category"}, reasons["schemas.Category"])
+		assert.Contains(t, reasons["schemas.Pet"], "GET /pet/{petId} responses.200.content.application/json.schema")
+		assert.NotContains(t, reasons, "schemas.Order", "Pruned components are not explained")
+	})
+
+	t.Run("schema kept by filter options", func(t *testing.T) {
+		reasons := client.ExplainPrune(doc, openax.FilterOptions{
+			Paths: []string{"/store/inventory"},
+			Keep:  map[string][]string{openax.ComponentSchemas: {"User"}},
+		})
+		require.NotNil(t, reasons)
+
+		assert.Equal(t, []string{"filter options"}, reasons["schemas.User"])
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		assert.Nil(t, client.ExplainPrune(doc, openax.FilterOptions{Schemas: []string{"Unicorn"}}))
+	})
+}
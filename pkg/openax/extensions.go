@@ -0,0 +1,221 @@
+package openax
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// extensionStripper removes matching vendor extensions from the filtered spec
+type extensionStripper struct {
+	patterns []string
+}
+
+// stripExtensions removes extensions matching the given names or prefixes from the
+// top-level document, operations, parameters, responses, and schemas
+func stripExtensions(filtered *openapi3.T, patterns []string) {
+	s := extensionStripper{patterns: patterns}
+
+	filtered.Extensions = s.strip(filtered.Extensions)
+
+	for path, pathItem := range filtered.Paths.Map() {
+		filtered.Paths.Set(path, s.pathItem(pathItem))
+	}
+
+	if filtered.Components == nil {
+		return
+	}
+	for name, schema := range filtered.Components.Schemas {
+		filtered.Components.Schemas[name] = s.schemaRef(schema)
+	}
+	for name, param := range filtered.Components.Parameters {
+		filtered.Components.Parameters[name] = s.parameterRef(param)
+	}
+	for name, requestBody := range filtered.Components.RequestBodies {
+		filtered.Components.RequestBodies[name] = s.requestBodyRef(requestBody)
+	}
+	for name, response := range filtered.Components.Responses {
+		filtered.Components.Responses[name] = s.responseRef(response)
+	}
+}
+
+// matches reports whether an extension key matches one of the patterns.
+// A pattern ending in "*" matches by prefix; only "x-" keys are ever matched.
+func (s extensionStripper) matches(key string) bool {
+	if !strings.HasPrefix(key, "x-") {
+		return false
+	}
+	for _, pattern := range s.patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// strip returns the extensions without matching keys, reusing the map if nothing matches
+func (s extensionStripper) strip(extensions map[string]any) map[string]any {
+	changed := false
+	for key := range extensions {
+		if s.matches(key) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return extensions
+	}
+
+	stripped := make(map[string]any, len(extensions))
+	for key, value := range extensions {
+		if !s.matches(key) {
+			stripped[key] = value
+		}
+	}
+	if len(stripped) == 0 {
+		return nil
+	}
+	return stripped
+}
+
+func (s extensionStripper) pathItem(pathItem *openapi3.PathItem) *openapi3.PathItem {
+	if pathItem == nil {
+		return nil
+	}
+	copied := *pathItem
+	copied.Parameters = s.parameters(pathItem.Parameters)
+	for method, operation := range pathItem.Operations() {
+		copied.SetOperation(method, s.operation(operation))
+	}
+	return &copied
+}
+
+func (s extensionStripper) operation(operation *openapi3.Operation) *openapi3.Operation {
+	if operation == nil {
+		return nil
+	}
+	copied := *operation
+	copied.Extensions = s.strip(operation.Extensions)
+	copied.Parameters = s.parameters(operation.Parameters)
+	copied.RequestBody = s.requestBodyRef(operation.RequestBody)
+
+	if operation.Responses != nil {
+		responses := &openapi3.Responses{Extensions: operation.Responses.Extensions}
+		for status, response := range operation.Responses.Map() {
+			responses.Set(status, s.responseRef(response))
+		}
+		copied.Responses = responses
+	}
+	return &copied
+}
+
+func (s extensionStripper) parameters(params openapi3.Parameters) openapi3.Parameters {
+	if params == nil {
+		return nil
+	}
+	copied := make(openapi3.Parameters, len(params))
+	for i, param := range params {
+		copied[i] = s.parameterRef(param)
+	}
+	return copied
+}
+
+func (s extensionStripper) parameterRef(ref *openapi3.ParameterRef) *openapi3.ParameterRef {
+	// Referenced components are stripped where they are defined
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return ref
+	}
+	value := *ref.Value
+	value.Extensions = s.strip(ref.Value.Extensions)
+	value.Schema = s.schemaRef(ref.Value.Schema)
+	value.Content = s.content(ref.Value.Content)
+
+	copied := *ref
+	copied.Extensions = s.strip(ref.Extensions)
+	copied.Value = &value
+	return &copied
+}
+
+func (s extensionStripper) requestBodyRef(ref *openapi3.RequestBodyRef) *openapi3.RequestBodyRef {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return ref
+	}
+	value := *ref.Value
+	value.Content = s.content(ref.Value.Content)
+
+	copied := *ref
+	copied.Value = &value
+	return &copied
+}
+
+func (s extensionStripper) responseRef(ref *openapi3.ResponseRef) *openapi3.ResponseRef {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return ref
+	}
+	value := *ref.Value
+	value.Extensions = s.strip(ref.Value.Extensions)
+	value.Content = s.content(ref.Value.Content)
+
+	copied := *ref
+	copied.Extensions = s.strip(ref.Extensions)
+	copied.Value = &value
+	return &copied
+}
+
+func (s extensionStripper) content(content openapi3.Content) openapi3.Content {
+	if content == nil {
+		return nil
+	}
+	copied := make(openapi3.Content, len(content))
+	for mimeType, mediaType := range content {
+		if mediaType == nil {
+			copied[mimeType] = nil
+			continue
+		}
+		mt := *mediaType
+		mt.Schema = s.schemaRef(mediaType.Schema)
+		copied[mimeType] = &mt
+	}
+	return copied
+}
+
+func (s extensionStripper) schemaRef(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return ref
+	}
+	value := *ref.Value
+	value.Extensions = s.strip(ref.Value.Extensions)
+	value.Items = s.schemaRef(ref.Value.Items)
+	value.Not = s.schemaRef(ref.Value.Not)
+	value.AllOf = s.schemaRefs(ref.Value.AllOf)
+	value.OneOf = s.schemaRefs(ref.Value.OneOf)
+	value.AnyOf = s.schemaRefs(ref.Value.AnyOf)
+	value.AdditionalProperties.Schema = s.schemaRef(ref.Value.AdditionalProperties.Schema)
+
+	if ref.Value.Properties != nil {
+		value.Properties = make(openapi3.Schemas, len(ref.Value.Properties))
+		for name, prop := range ref.Value.Properties {
+			value.Properties[name] = s.schemaRef(prop)
+		}
+	}
+
+	copied := *ref
+	copied.Extensions = s.strip(ref.Extensions)
+	copied.Value = &value
+	return &copied
+}
+
+func (s extensionStripper) schemaRefs(refs openapi3.SchemaRefs) openapi3.SchemaRefs {
+	if refs == nil {
+		return nil
+	}
+	copied := make(openapi3.SchemaRefs, len(refs))
+	for i, ref := range refs {
+		copied[i] = s.schemaRef(ref)
+	}
+	return copied
+}
@@ -0,0 +1,76 @@
+package openax
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripExtensions(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Extensions API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      x-internal-notes: do not publish
+      x-public-since: "1.0"
+      parameters:
+        - name: id
+          in: path
+          required: true
+          x-internal-owner: pets-team
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          x-internal-trace: true
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      x-internal-notes: legacy model
+      properties:
+        name:
+          type: string
+          x-internal-source: db.pets.name
+`))
+	require.NoError(t, err)
+
+	filteredDoc, err := applyFilter(doc, FilterOptions{
+		StripExtensions: []string{"x-internal*"},
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(filteredDoc)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "x-internal")
+	assert.Contains(t, string(data), "x-public-since")
+
+	// The source document must not be modified
+	assert.Contains(t, doc.Paths.Value("/pets/{id}").Get.Extensions, "x-internal-notes")
+	assert.Contains(t, doc.Components.Schemas["Pet"].Value.Extensions, "x-internal-notes")
+	assert.Contains(t, doc.Components.Schemas["Pet"].Value.Properties["name"].Value.Extensions, "x-internal-source")
+}
+
+func TestExtensionStripperMatches(t *testing.T) {
+	s := extensionStripper{patterns: []string{"x-internal*", "x-beta"}}
+
+	assert.True(t, s.matches("x-internal"))
+	assert.True(t, s.matches("x-internal-notes"))
+	assert.True(t, s.matches("x-beta"))
+	assert.False(t, s.matches("x-beta-flag"))
+	assert.False(t, s.matches("x-public"))
+	assert.False(t, s.matches(jsonSchemaDialectKey))
+}
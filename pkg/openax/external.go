@@ -0,0 +1,54 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExternalRefs lists the distinct $ref targets in a raw YAML or JSON specification
+// that point outside the document, i.e. every reference that is not a local "#/..."
+// pointer. It works on raw bytes so a spec can be audited before AllowExternalRefs
+// is enabled; only the given document is inspected, not the files it references.
+//
+// The references are returned sorted, exactly as written in the document.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("openapi.yaml")
+//	refs, err := openax.ExternalRefs(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(refs) // [./schemas/pet.yaml https://example.com/common.yaml#/Error]
+func ExternalRefs(data []byte) ([]string, error) {
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var spec any
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	var refs []string
+	var walk func(node any)
+	walk = func(node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			if ref, ok := value["$ref"].(string); ok && !strings.HasPrefix(ref, "#") && !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+			for _, child := range value {
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(spec)
+
+	slices.Sort(refs)
+	return refs, nil
+}
@@ -0,0 +1,66 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalRefs(t *testing.T) {
+	spec := []byte(`openapi: 3.0.3
+info:
+  title: Mixed Refs
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: 'paths/pets.yaml'
+  /owners:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - $ref: 'https://example.com/common.yaml#/parameters/Offset'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: './schemas/owner.yaml'
+        default:
+          $ref: 'https://example.com/common.yaml#/responses/Error'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        $ref: 'https://example.com/common.yaml#/parameters/Offset'
+  schemas:
+    Owner:
+      $ref: './schemas/owner.yaml'
+`)
+
+	refs, err := openax.ExternalRefs(spec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"./schemas/owner.yaml",
+		"https://example.com/common.yaml#/parameters/Offset",
+		"https://example.com/common.yaml#/responses/Error",
+		"paths/pets.yaml",
+	}, refs)
+
+	t.Run("json input", func(t *testing.T) {
+		refs, err := openax.ExternalRefs([]byte(`{"components": {"schemas": {"Pet": {"$ref": "#/components/schemas/Animal"}}}}`))
+		require.NoError(t, err)
+		assert.Empty(t, refs)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := openax.ExternalRefs([]byte("paths: ["))
+		assert.Error(t, err)
+	})
+}
@@ -2,8 +2,11 @@ package openax
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -17,6 +20,28 @@ func createLocation(specPath string) *SourceLocation {
 
 // applyFilter applies filtering to an OpenAPI specification based on the provided options.
 func applyFilter(doc *openapi3.T, opts FilterOptions) (*openapi3.T, error) {
+	return applyFilterWithReport(doc, opts, &FilterReport{})
+}
+
+// applyFilterWithReport applies filtering and records non-fatal issues in the report.
+//
+// The result shares retained objects with the source document. Every step that
+// rewrites one of them copies it first, so the source is never modified.
+func applyFilterWithReport(doc *openapi3.T, opts FilterOptions, report *FilterReport) (*openapi3.T, error) {
+	if err := validateFilterOptions(opts); err != nil {
+		return nil, err
+	}
+	source := doc
+
+	// Restrict the source to a per-tag sample of the matched paths
+	if opts.MaxPathsPerTag > 0 {
+		sampled, err := samplePathsPerTag(doc, opts)
+		if err != nil {
+			return nil, err
+		}
+		doc = sampled
+	}
+
 	filtered := createFilteredSpec(doc)
 	mimeTypes := findAllMimeTypes(doc)
 	usedTagNames := make(map[string]bool)
@@ -28,29 +53,164 @@ func applyFilter(doc *openapi3.T, opts FilterOptions) (*openapi3.T, error) {
 		Responses:     make(map[string]bool),
 	}
 
+	// Find the operations and tags the selection filters match
+	matcher, err := newOperationMatcher(doc, opts, mimeTypes)
+	if err != nil {
+		return nil, err
+	}
+	for _, schemaName := range opts.Schemas {
+		processedRefs.Schemas[schemaName] = true
+	}
+
+	// Reject selectors naming operations that do not exist
+	if err := checkOperationSelectors(doc, opts.OperationSelectors); err != nil {
+		return nil, err
+	}
+
+	// Force-include allowlisted components
+	if err := collectKeptComponents(doc, opts.Keep, processedRefs); err != nil {
+		return nil, err
+	}
+
 	// Process paths and operations
-	if err := processPathsAndOperations(doc, filtered, opts, mimeTypes, usedTagNames, processedRefs); err != nil {
+	if err := processPathsAndOperations(doc, filtered, matcher, mimeTypes, usedTagNames, processedRefs, report); err != nil {
 		return nil, err
 	}
 
+	// Pull in collection endpoints of retained item endpoints if enabled
+	if opts.IncludeParentPaths {
+		if err := includeParentPaths(doc, filtered, mimeTypes, usedTagNames, processedRefs, report); err != nil {
+			return nil, err
+		}
+	}
+
+	// Pull in operations targeted by response links if enabled
+	if opts.FollowLinks {
+		if err := followOperationLinks(doc, filtered, mimeTypes, usedTagNames, processedRefs, report); err != nil {
+			return nil, err
+		}
+	}
+
+	// Drop paths left without operations unless asked to keep them
+	if !opts.KeepEmptyPaths {
+		removeEmptyPaths(filtered)
+	}
+
 	// Process tags
-	processUsedTags(doc, filtered, usedTagNames)
+	processUsedTags(doc, filtered, usedTagNames, opts.StripUnusedTags)
 
 	// Resolve all collected references
 	if err := resolveAllReferences(doc, filtered, processedRefs); err != nil {
 		return nil, err
 	}
 
+	// Resolve 3.1 dynamic references, which kin-openapi leaves unresolved
+	if err := resolveDynamicRefs(doc, filtered, processedRefs, report); err != nil {
+		return nil, err
+	}
+
+	// Drop response links whose target operation was filtered out
+	if err := pruneDanglingLinks(doc, filtered, processedRefs, report); err != nil {
+		return nil, err
+	}
+
+	// Fold response content types into the canonical one if requested
+	if opts.CanonicalResponseContentType != "" {
+		emptied, err := foldResponseContentTypes(filtered, opts.CanonicalResponseContentType)
+		if err != nil {
+			return nil, err
+		}
+		// Report responses left without content unless asked not to
+		if !opts.KeepEmptyContent {
+			for _, at := range emptied {
+				report.warn(createLocation(at), "response has no content left after filtering")
+			}
+		}
+	}
+
 	// Prune unused components if enabled
 	if opts.PruneComponents {
-		pruneUnusedComponents(filtered, processedRefs)
+		noPrune := opts.NoPrune
+		if !opts.PruneSecuritySchemes {
+			noPrune = append(slices.Clone(noPrune), ComponentSecuritySchemes)
+		}
+		pruneUnusedComponents(doc, filtered, processedRefs, noPrune)
 	}
 
+	// Rename operationIds if requested
+	if err := normalizeOperationIDs(filtered, opts.OperationIDCase); err != nil {
+		return nil, err
+	}
+
+	// Strip vendor extensions if requested
+	if len(opts.StripExtensions) > 0 {
+		stripExtensions(filtered, opts.StripExtensions)
+	}
+
+	// Substitute server variable defaults if requested
+	if opts.ResolveServerVariables {
+		if err := resolveServerVariables(filtered); err != nil {
+			return nil, err
+		}
+	}
+
+	// Mark the subset's version if requested
+	if opts.VersionSuffix != "" {
+		appendVersionSuffix(filtered, opts.VersionSuffix)
+	}
+
+	// Record how the spec was produced if requested
+	if opts.EmbedProvenance {
+		embedProvenance(filtered, opts, time.Now())
+	}
+
+	// Detach the result from the source document if requested
+	if opts.DeepCopy {
+		filtered = deepCopySpec(filtered)
+	}
+
+	report.recordRemovedOperations(source, filtered)
+	report.sortWarnings()
 	return filtered, nil
 }
 
-// pruneUnusedComponents removes components that are not referenced by the filtered spec
-func pruneUnusedComponents(filtered *openapi3.T, processedRefs *ProcessedRefs) {
+// validateFilterOptions rejects option values that cannot be applied
+func validateFilterOptions(opts FilterOptions) error {
+	for _, category := range opts.NoPrune {
+		if !slices.Contains(pruneCategories, category) {
+			return fmt.Errorf("unknown component category %q (supported: %s)", category, strings.Join(pruneCategories, ", "))
+		}
+	}
+
+	if opts.MaxPathsPerTag < 0 {
+		return fmt.Errorf("max paths per tag must not be negative: %d", opts.MaxPathsPerTag)
+	}
+
+	if err := validateKeepCategories(opts.Keep); err != nil {
+		return err
+	}
+
+	switch opts.OperationIDCase {
+	case OperationIDCasePreserve, OperationIDCaseCamel, OperationIDCaseSnake, OperationIDCaseKebab:
+	default:
+		return fmt.Errorf("unsupported operationId case: %q", opts.OperationIDCase)
+	}
+
+	return nil
+}
+
+// pruneCategories lists the component categories that pruning can be disabled for
+var pruneCategories = []string{
+	ComponentSchemas,
+	ComponentParameters,
+	ComponentRequestBodies,
+	ComponentResponses,
+	ComponentSecuritySchemes,
+}
+
+// pruneUnusedComponents removes components that are not referenced by the filtered spec.
+// Categories listed in noPrune are left intact.
+func pruneUnusedComponents(doc *openapi3.T, filtered *openapi3.T, processedRefs *ProcessedRefs, noPrune []string) {
 	if filtered.Components == nil {
 		return
 	}
@@ -67,32 +227,45 @@ func pruneUnusedComponents(filtered *openapi3.T, processedRefs *ProcessedRefs) {
 	findTransitivelyUsedComponents(filtered, usedComponents)
 
 	// Remove unused schemas
-	for schemaName := range filtered.Components.Schemas {
-		if !usedComponents.Schemas[schemaName] {
-			delete(filtered.Components.Schemas, schemaName)
+	if !slices.Contains(noPrune, ComponentSchemas) {
+		for schemaName := range filtered.Components.Schemas {
+			if !usedComponents.Schemas[schemaName] {
+				delete(filtered.Components.Schemas, schemaName)
+			}
 		}
 	}
 
 	// Remove unused parameters
-	for paramName := range filtered.Components.Parameters {
-		if !usedComponents.Parameters[paramName] {
-			delete(filtered.Components.Parameters, paramName)
+	if !slices.Contains(noPrune, ComponentParameters) {
+		for paramName := range filtered.Components.Parameters {
+			if !usedComponents.Parameters[paramName] {
+				delete(filtered.Components.Parameters, paramName)
+			}
 		}
 	}
 
 	// Remove unused request bodies
-	for rbName := range filtered.Components.RequestBodies {
-		if !usedComponents.RequestBodies[rbName] {
-			delete(filtered.Components.RequestBodies, rbName)
+	if !slices.Contains(noPrune, ComponentRequestBodies) {
+		for rbName := range filtered.Components.RequestBodies {
+			if !usedComponents.RequestBodies[rbName] {
+				delete(filtered.Components.RequestBodies, rbName)
+			}
 		}
 	}
 
 	// Remove unused responses
-	for respName := range filtered.Components.Responses {
-		if !usedComponents.Responses[respName] {
-			delete(filtered.Components.Responses, respName)
+	if !slices.Contains(noPrune, ComponentResponses) {
+		for respName := range filtered.Components.Responses {
+			if !usedComponents.Responses[respName] {
+				delete(filtered.Components.Responses, respName)
+			}
 		}
 	}
+
+	// Remove unused security schemes and OAuth2 scopes
+	if !slices.Contains(noPrune, ComponentSecuritySchemes) {
+		pruneSecuritySchemes(doc, filtered)
+	}
 }
 
 // ComponentUsage tracks which components are used
@@ -103,98 +276,109 @@ type ComponentUsage struct {
 	Responses     map[string]bool
 }
 
-// findTransitivelyUsedComponents finds all components that are transitively referenced
+// findTransitivelyUsedComponents finds all components that are transitively referenced.
+// Direct references are indexed once per component, then a single traversal marks
+// every schema reachable from the initially used components.
 func findTransitivelyUsedComponents(filtered *openapi3.T, usage *ComponentUsage) {
-	// Keep iterating until no new components are found
-	for {
-		changed := false
-		changed = processSchemaTransitiveRefs(filtered, usage) || changed
-		changed = processParameterTransitiveRefs(filtered, usage) || changed
-		changed = processRequestBodyTransitiveRefs(filtered, usage) || changed
-		changed = processResponseTransitiveRefs(filtered, usage) || changed
-
-		if !changed {
-			break
-		}
-	}
+	expandComponentUsage(buildComponentDependencies(filtered.Components), usage)
 }
 
-func processSchemaTransitiveRefs(filtered *openapi3.T, usage *ComponentUsage) bool {
-	changed := false
+// expandComponentUsage marks every schema reachable from the used components
+func expandComponentUsage(deps *componentDependencies, usage *ComponentUsage) {
+	queue := make([]string, 0, len(usage.Schemas))
 	for schemaName := range usage.Schemas {
-		if schema, exists := filtered.Components.Schemas[schemaName]; exists && schema != nil {
-			refs := make(map[string]bool)
-			if err := extractSchemaReferences(schema, refs); err == nil {
-				for refName := range refs {
-					if !usage.Schemas[refName] {
-						usage.Schemas[refName] = true
-						changed = true
-					}
-				}
+		queue = append(queue, schemaName)
+	}
+
+	markUsed := func(refs []string) {
+		for _, refName := range refs {
+			if !usage.Schemas[refName] {
+				usage.Schemas[refName] = true
+				queue = append(queue, refName)
 			}
 		}
 	}
-	return changed
-}
 
-func processParameterTransitiveRefs(filtered *openapi3.T, usage *ComponentUsage) bool {
-	changed := false
 	for paramName := range usage.Parameters {
-		if param, exists := filtered.Components.Parameters[paramName]; exists && param.Value != nil && param.Value.Schema != nil {
-			refs := make(map[string]bool)
-			if err := extractSchemaReferences(param.Value.Schema, refs); err == nil {
-				for refName := range refs {
-					if !usage.Schemas[refName] {
-						usage.Schemas[refName] = true
-						changed = true
-					}
-				}
-			}
-		}
+		markUsed(deps.Parameters[paramName])
+	}
+	for rbName := range usage.RequestBodies {
+		markUsed(deps.RequestBodies[rbName])
+	}
+	for respName := range usage.Responses {
+		markUsed(deps.Responses[respName])
+	}
+
+	for len(queue) > 0 {
+		schemaName := queue[0]
+		queue = queue[1:]
+		markUsed(deps.Schemas[schemaName])
 	}
-	return changed
 }
 
-func processRequestBodyTransitiveRefs(filtered *openapi3.T, usage *ComponentUsage) bool {
-	changed := false
-	for rbName := range usage.RequestBodies {
-		if rb, exists := filtered.Components.RequestBodies[rbName]; exists && rb.Value != nil {
-			if processContentSchemaRefs(rb.Value.Content, usage) {
-				changed = true
-			}
+// componentDependencies indexes the schemas directly referenced by each component
+type componentDependencies struct {
+	Schemas       map[string][]string
+	Parameters    map[string][]string
+	RequestBodies map[string][]string
+	Responses     map[string][]string
+}
+
+// buildComponentDependencies extracts the direct schema references of every component once
+func buildComponentDependencies(components *openapi3.Components) *componentDependencies {
+	deps := &componentDependencies{
+		Schemas:       make(map[string][]string),
+		Parameters:    make(map[string][]string),
+		RequestBodies: make(map[string][]string),
+		Responses:     make(map[string][]string),
+	}
+
+	for schemaName, schema := range components.Schemas {
+		if schema != nil {
+			deps.Schemas[schemaName] = schemaDependencies(schema)
 		}
 	}
-	return changed
-}
 
-func processResponseTransitiveRefs(filtered *openapi3.T, usage *ComponentUsage) bool {
-	changed := false
-	for respName := range usage.Responses {
-		if resp, exists := filtered.Components.Responses[respName]; exists && resp.Value != nil {
-			if processContentSchemaRefs(resp.Value.Content, usage) {
-				changed = true
-			}
+	for paramName, param := range components.Parameters {
+		if param != nil && param.Value != nil && param.Value.Schema != nil {
+			deps.Parameters[paramName] = schemaDependencies(param.Value.Schema)
+		}
+	}
+
+	for rbName, rb := range components.RequestBodies {
+		if rb != nil && rb.Value != nil {
+			deps.RequestBodies[rbName] = contentDependencies(rb.Value.Content)
 		}
 	}
-	return changed
+
+	for respName, resp := range components.Responses {
+		if resp != nil && resp.Value != nil {
+			deps.Responses[respName] = contentDependencies(resp.Value.Content)
+		}
+	}
+
+	return deps
+}
+
+// schemaDependencies returns the schema names referenced by a schema, or nil if
+// its references cannot be extracted
+func schemaDependencies(schema *openapi3.SchemaRef) []string {
+	refs := make(map[string]bool)
+	if err := extractSchemaReferences(schema, refs); err != nil {
+		return nil
+	}
+	return sortedKeys(refs)
 }
 
-func processContentSchemaRefs(content openapi3.Content, usage *ComponentUsage) bool {
-	changed := false
+// contentDependencies returns the schema names referenced by all media types in content
+func contentDependencies(content openapi3.Content) []string {
+	var deps []string
 	for _, mediaType := range content {
 		if mediaType.Schema != nil {
-			refs := make(map[string]bool)
-			if err := extractSchemaReferences(mediaType.Schema, refs); err == nil {
-				for refName := range refs {
-					if !usage.Schemas[refName] {
-						usage.Schemas[refName] = true
-						changed = true
-					}
-				}
-			}
+			deps = append(deps, schemaDependencies(mediaType.Schema)...)
 		}
 	}
-	return changed
+	return deps
 }
 
 // ProcessedRefs holds all processed reference maps
@@ -205,6 +389,16 @@ type ProcessedRefs struct {
 	Responses     map[string]bool
 }
 
+// jsonSchemaDialectKey is the OpenAPI 3.1 top-level field declaring the default
+// JSON Schema dialect. kin-openapi has no dedicated field for it, so it is
+// decoded into the document's Extensions map.
+const jsonSchemaDialectKey = "jsonSchemaDialect"
+
+// webhooksKey is the OpenAPI 3.1 top-level field holding webhooks. kin-openapi does
+// not model webhooks either, so they are decoded into the Extensions map and cannot
+// be filtered.
+const webhooksKey = "webhooks"
+
 // createFilteredSpec creates the initial filtered OpenAPI spec structure
 func createFilteredSpec(doc *openapi3.T) *openapi3.T {
 	filtered := &openapi3.T{
@@ -222,37 +416,58 @@ func createFilteredSpec(doc *openapi3.T) *openapi3.T {
 		},
 	}
 
+	// Preserve the 3.1 JSON Schema dialect so schemas keep their meaning
+	if dialect, ok := doc.Extensions[jsonSchemaDialectKey]; ok {
+		filtered.Extensions = map[string]any{jsonSchemaDialectKey: dialect}
+	}
+
 	if doc.Components != nil {
-		filtered.Components.Headers = doc.Components.Headers
-		filtered.Components.SecuritySchemes = doc.Components.SecuritySchemes
-		filtered.Components.Examples = doc.Components.Examples
-		filtered.Components.Links = doc.Components.Links
-		filtered.Components.Callbacks = doc.Components.Callbacks
+		// Clone the maps so changes to the filtered spec's components cannot leak
+		// into the source; the component values themselves are still shared
+		filtered.Components.Headers = maps.Clone(doc.Components.Headers)
+		filtered.Components.SecuritySchemes = maps.Clone(doc.Components.SecuritySchemes)
+		filtered.Components.Examples = maps.Clone(doc.Components.Examples)
+		filtered.Components.Links = maps.Clone(doc.Components.Links)
+		filtered.Components.Callbacks = maps.Clone(doc.Components.Callbacks)
 	}
 
 	return filtered
 }
 
 // processPathsAndOperations processes all paths and operations based on filter options
-func processPathsAndOperations(doc *openapi3.T, filtered *openapi3.T, opts FilterOptions, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs) error {
+func processPathsAndOperations(doc *openapi3.T, filtered *openapi3.T, matcher *operationMatcher, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs, report *FilterReport) error {
+	// Webhooks are not filtered, so the filtered spec never contains them
+	if _, ok := doc.Extensions[webhooksKey]; ok {
+		report.warn(createLocation(webhooksKey), "webhooks are not supported and were dropped from the filtered spec")
+	}
+
 	for path, pathItem := range doc.Paths.Map() {
+		pathItem, err := resolvePathItem(doc, path, pathItem)
+		if err != nil {
+			return err
+		}
+
 		// Include entire path if it's in the paths list
-		if len(opts.Paths) > 0 && pathMatchesFilter(path, opts.Paths) {
+		if matcher.selectsPath(path) {
 			filtered.Paths.Set(path, pathItem)
-			if err := processAllOperationsInPath(doc, pathItem, mimeTypes, usedTagNames, processedRefs); err != nil {
+			if err := processAllOperationsInPath(doc, path, pathItem, mimeTypes, usedTagNames, processedRefs, report); err != nil {
 				return err
 			}
 			continue
 		}
 
 		// Check for operations that match filters
-		matchedOps, err := findMatchingOperations(doc, pathItem, opts, mimeTypes, usedTagNames, processedRefs)
+		matchedOps, err := findMatchingOperations(doc, path, pathItem, matcher, mimeTypes, usedTagNames, processedRefs, report)
 		if err != nil {
 			return err
 		}
 
 		if len(matchedOps) > 0 {
-			pItem := &openapi3.PathItem{}
+			// Path-level parameters apply to every retained operation
+			if err := collectPathParameters(doc, pathItem, processedRefs); err != nil {
+				return err
+			}
+			pItem := &openapi3.PathItem{Parameters: pathItem.Parameters}
 			for method, operation := range matchedOps {
 				pItem.SetOperation(method, operation)
 			}
@@ -262,9 +477,60 @@ func processPathsAndOperations(doc *openapi3.T, filtered *openapi3.T, opts Filte
 	return nil
 }
 
+// includeParentPaths retains the parent path (one segment up) of every retained
+// path ending in a path parameter, e.g. /pets for /pets/{id}. Parent paths that
+// exist in the source are included in full; partially retained parents are kept as is.
+func includeParentPaths(doc *openapi3.T, filtered *openapi3.T, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs, report *FilterReport) error {
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		parent, ok := parentPath(path)
+		if !ok || filtered.Paths.Value(parent) != nil {
+			continue
+		}
+
+		pathItem, err := resolvePathItem(doc, parent, doc.Paths.Value(parent))
+		if err != nil {
+			return err
+		}
+		if pathItem == nil {
+			continue
+		}
+		filtered.Paths.Set(parent, pathItem)
+		if err := processAllOperationsInPath(doc, parent, pathItem, mimeTypes, usedTagNames, processedRefs, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeEmptyPaths deletes the paths of the filtered spec that have no operations
+func removeEmptyPaths(filtered *openapi3.T) {
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		if pathItem := filtered.Paths.Value(path); pathItem == nil || len(pathItem.Operations()) == 0 {
+			filtered.Paths.Delete(path)
+		}
+	}
+}
+
+// parentPath returns the path one segment up from a path whose last segment is
+// a path parameter (e.g., "/pets/{id}" -> "/pets")
+func parentPath(path string) (string, bool) {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "", false
+	}
+	last := path[i+1:]
+	if !strings.HasPrefix(last, "{") || !strings.HasSuffix(last, "}") {
+		return "", false
+	}
+	return path[:i], true
+}
+
 // processAllOperationsInPath processes all operations in a path item
-func processAllOperationsInPath(doc *openapi3.T, pathItem *openapi3.PathItem, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs) error {
-	for _, operation := range pathItem.Operations() {
+func processAllOperationsInPath(doc *openapi3.T, path string, pathItem *openapi3.PathItem, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs, report *FilterReport) error {
+	if err := collectPathParameters(doc, pathItem, processedRefs); err != nil {
+		return err
+	}
+	for method, operation := range pathItem.Operations() {
 		if operation != nil {
 			err := collectReferencesFromOperation(doc, operation, mimeTypes,
 				processedRefs.Schemas, processedRefs.RequestBodies,
@@ -274,20 +540,27 @@ func processAllOperationsInPath(doc *openapi3.T, pathItem *openapi3.PathItem, mi
 			}
 
 			// Collect tags used by this operation
-			for _, tag := range operation.Tags {
-				usedTagNames[tag] = true
-			}
+			collectOperationTags(doc, path, method, operation, usedTagNames, report)
 		}
 	}
 	return nil
 }
 
+// collectPathParameters tracks the references of a path item's path-level parameters
+func collectPathParameters(doc *openapi3.T, pathItem *openapi3.PathItem, processedRefs *ProcessedRefs) error {
+	if len(pathItem.Parameters) == 0 {
+		return nil
+	}
+	shared := &openapi3.Operation{Parameters: pathItem.Parameters}
+	return processOperationParameters(doc, shared, processedRefs.Schemas, processedRefs.Parameters)
+}
+
 // findMatchingOperations finds operations that match the filter criteria
-func findMatchingOperations(doc *openapi3.T, pathItem *openapi3.PathItem, opts FilterOptions, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs) (map[string]*openapi3.Operation, error) {
+func findMatchingOperations(doc *openapi3.T, path string, pathItem *openapi3.PathItem, matcher *operationMatcher, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs, report *FilterReport) (map[string]*openapi3.Operation, error) {
 	matchedOps := make(map[string]*openapi3.Operation)
 
 	for method, operation := range pathItem.Operations() {
-		if operationMatches := checkOperationMatches(operation, method, opts); operationMatches {
+		if matcher.selects(path, method, operation) {
 			matchedOps[method] = operation
 
 			// Process references and tags for matched operation
@@ -299,17 +572,85 @@ func findMatchingOperations(doc *openapi3.T, pathItem *openapi3.PathItem, opts F
 			}
 
 			// Collect tags used by this operation
-			for _, tag := range operation.Tags {
-				usedTagNames[tag] = true
-			}
+			collectOperationTags(doc, path, method, operation, usedTagNames, report)
 		}
 	}
 
 	return matchedOps, nil
 }
 
-// checkOperationMatches checks if an operation matches the filter criteria
-func checkOperationMatches(operation *openapi3.Operation, method string, opts FilterOptions) bool {
+// tagSelected reports whether a tag matches any of the tag patterns. A pattern
+// ending in "/*" matches the direct children of its prefix in a slash-delimited
+// tag hierarchy, and one ending in "/**" matches all of its descendants; neither
+// matches the prefix tag itself. Other patterns match the tag exactly.
+func tagSelected(patterns []string, tag string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(tag, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if child, ok := strings.CutPrefix(tag, prefix+"/"); ok && child != "" && !strings.Contains(child, "/") {
+				return true
+			}
+			continue
+		}
+		if pattern == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// operationMatcher decides which operations the selection filters pick. The match
+// sets that depend on the whole document are computed once, up front.
+type operationMatcher struct {
+	opts          FilterOptions
+	schemaOps     map[*openapi3.Operation]bool // Operations using one of opts.Schemas
+	parameterOps  map[*openapi3.Operation]bool // Operations declaring one of opts.HasParameters
+	describedTags map[string]bool              // Tags whose description matches opts.TagDescriptionMatch
+}
+
+// newOperationMatcher precomputes the match sets the options need
+func newOperationMatcher(doc *openapi3.T, opts FilterOptions, mimeTypes []string) (*operationMatcher, error) {
+	m := &operationMatcher{opts: opts}
+
+	var err error
+	if len(opts.Schemas) > 0 {
+		if m.schemaOps, err = findOperationsUsingSchemas(doc, opts.Schemas, mimeTypes); err != nil {
+			return nil, err
+		}
+	}
+	if opts.TagDescriptionMatch != "" {
+		if m.describedTags, err = findTagsByDescription(doc, opts.TagDescriptionMatch); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.HasParameters) > 0 {
+		if m.parameterOps, err = findOperationsWithParameters(doc, opts.HasParameters); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// selectsPath reports whether a path is selected whole by the path filter
+func (m *operationMatcher) selectsPath(path string) bool {
+	return len(m.opts.Paths) > 0 && pathMatchesFilter(path, m.opts.Paths)
+}
+
+// selects reports whether an operation matches the filter criteria or is named by
+// an operation selector
+func (m *operationMatcher) selects(path, method string, operation *openapi3.Operation) bool {
+	return m.matches(operation, method) || operationSelected(m.opts.OperationSelectors, path, method)
+}
+
+// matches checks if an operation matches the filter criteria
+func (m *operationMatcher) matches(operation *openapi3.Operation, method string) bool {
+	opts := m.opts
 	operationMatches := true
 
 	// Check operation filter (if specified)
@@ -324,7 +665,7 @@ func checkOperationMatches(operation *openapi3.Operation, method string, opts Fi
 	if len(opts.Tags) > 0 && operationMatches {
 		tagMatches := false
 		for _, operationTag := range operation.Tags {
-			if slices.Contains(opts.Tags, operationTag) {
+			if tagSelected(opts.Tags, operationTag) {
 				tagMatches = true
 				break
 			}
@@ -332,12 +673,87 @@ func checkOperationMatches(operation *openapi3.Operation, method string, opts Fi
 		operationMatches = operationMatches && tagMatches
 	}
 
+	// Check tag description filter (if specified) - must use at least one matching tag
+	if opts.TagDescriptionMatch != "" && operationMatches {
+		operationMatches = slices.ContainsFunc(operation.Tags, func(tag string) bool {
+			return m.describedTags[tag]
+		})
+	}
+
+	// Check schema filter (if specified) - must use at least one of the schemas
+	if len(opts.Schemas) > 0 && operationMatches {
+		operationMatches = m.schemaOps[operation]
+	}
+
+	// Check parameter filter (if specified) - must declare at least one of the parameters
+	if len(opts.HasParameters) > 0 && operationMatches {
+		operationMatches = m.parameterOps[operation]
+	}
+
+	// Check request body filter (if specified) - must require its request body
+	if opts.RequireRequestBody && operationMatches {
+		operationMatches = operation.RequestBody != nil && operation.RequestBody.Value != nil &&
+			operation.RequestBody.Value.Required
+	}
+
 	// Include if all specified filters match
-	return operationMatches && (len(opts.Operations) > 0 || len(opts.Tags) > 0 || (len(opts.Operations) == 0 && len(opts.Tags) == 0 && len(opts.Paths) == 0))
+	hasOperationFilters := len(opts.Operations) > 0 || len(opts.Tags) > 0 || opts.TagDescriptionMatch != "" ||
+		len(opts.Schemas) > 0 || len(opts.HasParameters) > 0 || opts.RequireRequestBody
+	return operationMatches && (hasOperationFilters || (len(opts.Paths) == 0 && len(opts.OperationSelectors) == 0))
 }
 
-// processUsedTags processes tags that are used by filtered operations
-func processUsedTags(doc *openapi3.T, filtered *openapi3.T, usedTagNames map[string]bool) {
+// findTagsByDescription returns the names of top-level tags whose description
+// matches the regular expression
+func findTagsByDescription(doc *openapi3.T, pattern string) (map[string]bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag description pattern %q: %w", pattern, err)
+	}
+
+	matched := make(map[string]bool)
+	for _, tag := range doc.Tags {
+		if tag != nil && re.MatchString(tag.Description) {
+			matched[tag.Name] = true
+		}
+	}
+	return matched, nil
+}
+
+// collectOperationTags records the tags used by an operation, warning about tags
+// that are not declared in the document's top-level tags
+func collectOperationTags(doc *openapi3.T, path, method string, operation *openapi3.Operation, usedTagNames map[string]bool, report *FilterReport) {
+	for _, tag := range operation.Tags {
+		usedTagNames[tag] = true
+
+		if doc.Tags.Get(tag) == nil {
+			report.warn(operationLocation(path, method), "operation uses tag %q which is not declared in top-level tags", tag)
+		}
+	}
+}
+
+// operationLocation creates a SourceLocation for an operation
+func operationLocation(path, method string) *SourceLocation {
+	return createLocation(fmt.Sprintf("paths.%s.%s", path, strings.ToLower(method)))
+}
+
+// appendVersionSuffix appends suffix to the filtered info.version
+func appendVersionSuffix(filtered *openapi3.T, suffix string) {
+	info := openapi3.Info{}
+	if filtered.Info != nil {
+		info = *filtered.Info
+	}
+	info.Version += suffix
+	filtered.Info = &info
+}
+
+// processUsedTags processes tags that are used by filtered operations. Unused tags
+// are stripped unless stripUnused is explicitly false, in which case all are kept.
+func processUsedTags(doc *openapi3.T, filtered *openapi3.T, usedTagNames map[string]bool, stripUnused *bool) {
+	if stripUnused != nil && !*stripUnused {
+		filtered.Tags = doc.Tags
+		return
+	}
+
 	if len(usedTagNames) > 0 {
 		filtered.Tags = make(openapi3.Tags, 0)
 
@@ -364,7 +780,7 @@ func resolveAllReferences(doc *openapi3.T, filtered *openapi3.T, processedRefs *
 		if err := resolveRequestBodyRefs(doc, filtered, processedRefs.RequestBodies); err != nil {
 			return err
 		}
-		if err := resolveParameterRefs(doc, filtered, processedRefs.Parameters); err != nil {
+		if err := resolveParameterRefs(doc, filtered, processedRefs); err != nil {
 			return err
 		}
 		if err := resolveResponseRefs(doc, filtered, processedRefs.Responses); err != nil {
@@ -387,14 +803,29 @@ func resolveRequestBodyRefs(doc *openapi3.T, filtered *openapi3.T, requestBodyRe
 	return nil
 }
 
-// resolveParameterRefs resolves parameter references
-func resolveParameterRefs(doc *openapi3.T, filtered *openapi3.T, parameterRefs map[string]bool) error {
-	for paramName := range parameterRefs {
+// resolveParameterRefs resolves parameter references, along with the schemas the
+// resolved parameters reference and their transitive closure
+func resolveParameterRefs(doc *openapi3.T, filtered *openapi3.T, processedRefs *ProcessedRefs) error {
+	for paramName := range processedRefs.Parameters {
 		param, ok := doc.Components.Parameters[paramName]
 		if !ok {
 			return &ComponentNotFoundError{Name: paramName, Type: "parameter"}
 		}
 		filtered.Components.Parameters[paramName] = param
+
+		schemaRefs := make(map[string]bool)
+		if err := extractParameterSchemaReferences(param.Value, schemaRefs); err != nil {
+			return err
+		}
+		for schemaName := range schemaRefs {
+			if processedRefs.Schemas[schemaName] {
+				continue
+			}
+			processedRefs.Schemas[schemaName] = true
+			if err := resolveSchemaRefsRecursively(doc, filtered, schemaName, make(map[string]bool), "components.parameters."+paramName); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -445,6 +876,26 @@ func validateRef(ref string, location *SourceLocation) (string, error) {
 	return extractRefName(ref), nil
 }
 
+// validateComponentRef checks a reference like validateRef and also that it points
+// into the component category expected at its position, e.g. that a schema $ref
+// targets components/schemas rather than components/parameters
+func validateComponentRef(ref, category string, location *SourceLocation) (string, error) {
+	name, err := validateRef(ref, location)
+	if err != nil {
+		return "", err
+	}
+
+	actual, _, _ := strings.Cut(strings.TrimPrefix(ref, "#/components/"), "/")
+	if actual != category {
+		return "", InvalidReferenceError{
+			Ref:      ref,
+			Reason:   fmt.Sprintf("expected a reference to %s, not %s", category, actual),
+			Location: location,
+		}
+	}
+	return name, nil
+}
+
 // collectReferencesFromOperation extracts all references from an operation and tracks them
 func collectReferencesFromOperation(
 	doc *openapi3.T,
@@ -470,6 +921,81 @@ func collectReferencesFromOperation(
 		return err
 	}
 
+	// Process callback operations
+	if err := processOperationCallbacks(doc, operation, mimeTypes,
+		processedSchemaRefs, processedRequestBodyRefs, processedParameterRefs, processedResponseRefs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// processOperationCallbacks processes references in the operations of an operation's
+// callbacks, including nested callbacks
+func processOperationCallbacks(
+	doc *openapi3.T,
+	operation *openapi3.Operation,
+	mimeTypes []string,
+	processedSchemaRefs map[string]bool,
+	processedRequestBodyRefs map[string]bool,
+	processedParameterRefs map[string]bool,
+	processedResponseRefs map[string]bool,
+) error {
+	visited := map[*openapi3.Operation]bool{operation: true}
+	pending := []*openapi3.Operation{operation}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for _, callbackRef := range current.Callbacks {
+			callback := resolveCallback(doc, callbackRef)
+			if callback == nil {
+				continue
+			}
+
+			for _, pathItem := range callback.Map() {
+				if pathItem == nil {
+					continue
+				}
+				for _, callbackOp := range pathItem.Operations() {
+					if callbackOp == nil || visited[callbackOp] {
+						continue
+					}
+					visited[callbackOp] = true
+					pending = append(pending, callbackOp)
+
+					if err := processOperationRequestBody(doc, callbackOp, mimeTypes, processedSchemaRefs, processedRequestBodyRefs); err != nil {
+						return err
+					}
+					if err := processOperationParameters(doc, callbackOp, processedSchemaRefs, processedParameterRefs); err != nil {
+						return err
+					}
+					if err := processOperationResponses(doc, callbackOp, mimeTypes, processedSchemaRefs, processedResponseRefs); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveCallback returns the callback value, looking up component references when
+// the loader has not populated the value
+func resolveCallback(doc *openapi3.T, callbackRef *openapi3.CallbackRef) *openapi3.Callback {
+	if callbackRef == nil {
+		return nil
+	}
+	if callbackRef.Value != nil {
+		return callbackRef.Value
+	}
+	if callbackRef.Ref != "" && doc.Components != nil {
+		if component, ok := doc.Components.Callbacks[extractRefName(callbackRef.Ref)]; ok && component != nil {
+			return component.Value
+		}
+	}
 	return nil
 }
 
@@ -480,7 +1006,7 @@ func processOperationRequestBody(doc *openapi3.T, operation *openapi3.Operation,
 	}
 
 	if operation.RequestBody.Ref != "" {
-		requestBodyName, err := validateRef(operation.RequestBody.Ref, createLocation("requestBody"))
+		requestBodyName, err := validateComponentRef(operation.RequestBody.Ref, ComponentRequestBodies, createLocation("requestBody"))
 		if err != nil {
 			return err
 		}
@@ -502,7 +1028,7 @@ func processOperationRequestBody(doc *openapi3.T, operation *openapi3.Operation,
 func processOperationParameters(doc *openapi3.T, operation *openapi3.Operation, processedSchemaRefs map[string]bool, processedParameterRefs map[string]bool) error {
 	for _, param := range operation.Parameters {
 		if param.Ref != "" {
-			paramName, err := validateRef(param.Ref, createLocation("parameter"))
+			paramName, err := validateComponentRef(param.Ref, ComponentParameters, createLocation("parameter"))
 			if err != nil {
 				return err
 			}
@@ -510,20 +1036,32 @@ func processOperationParameters(doc *openapi3.T, operation *openapi3.Operation,
 
 			// Get the actual parameter to check its schema
 			if parameter, ok := doc.Components.Parameters[paramName]; ok {
-				if parameter.Value != nil && parameter.Value.Schema != nil && parameter.Value.Schema.Ref != "" {
-					schemaName, err := validateRef(parameter.Value.Schema.Ref, createLocation("parameter.schema"))
-					if err != nil {
-						return err
-					}
-					processedSchemaRefs[schemaName] = true
+				if err := extractParameterSchemaReferences(parameter.Value, processedSchemaRefs); err != nil {
+					return err
 				}
 			}
-		} else if param.Value != nil && param.Value.Schema != nil && param.Value.Schema.Ref != "" {
-			schemaName, err := validateRef(param.Value.Schema.Ref, createLocation("parameter.schema"))
-			if err != nil {
-				return err
-			}
-			processedSchemaRefs[schemaName] = true
+		} else if err := extractParameterSchemaReferences(param.Value, processedSchemaRefs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractParameterSchemaReferences extracts the schema references of a parameter's
+// schema and content, including those nested in inline subschemas
+func extractParameterSchemaReferences(param *openapi3.Parameter, processedSchemaRefs map[string]bool) error {
+	if param == nil {
+		return nil
+	}
+	if err := extractSchemaReferences(param.Schema, processedSchemaRefs); err != nil {
+		return err
+	}
+	for _, mediaType := range param.Content {
+		if mediaType == nil {
+			continue
+		}
+		if err := extractSchemaReferences(mediaType.Schema, processedSchemaRefs); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -533,7 +1071,7 @@ func processOperationParameters(doc *openapi3.T, operation *openapi3.Operation,
 func processOperationResponses(doc *openapi3.T, operation *openapi3.Operation, mimeTypes []string, processedSchemaRefs map[string]bool, processedResponseRefs map[string]bool) error {
 	for _, response := range operation.Responses.Map() {
 		if response.Ref != "" {
-			responseName, err := validateRef(response.Ref, createLocation("response"))
+			responseName, err := validateComponentRef(response.Ref, ComponentResponses, createLocation("response"))
 			if err != nil {
 				return err
 			}
@@ -554,20 +1092,49 @@ func processOperationResponses(doc *openapi3.T, operation *openapi3.Operation, m
 	return nil
 }
 
-// processContentSchemas processes schemas in content for different MIME types
+// processContentSchemas processes schemas in content for different MIME types.
+// Media type parameters (e.g., "; charset=utf-8") are ignored when matching, and
+// "*/*" or "type/*" wildcards on either side match any compatible type.
 func processContentSchemas(content openapi3.Content, mimeTypes []string, processedSchemaRefs map[string]bool) error {
-	for _, mimeType := range mimeTypes {
-		if mediaType := content.Get(mimeType); mediaType != nil {
-			if mediaType.Schema != nil {
-				if err := extractSchemaReferences(mediaType.Schema, processedSchemaRefs); err != nil {
-					return err
-				}
-			}
+	for contentType, mediaType := range content {
+		if mediaType == nil || mediaType.Schema == nil || !mimeTypeMatchesAny(contentType, mimeTypes) {
+			continue
+		}
+		if err := extractSchemaReferences(mediaType.Schema, processedSchemaRefs); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// mimeTypeMatchesAny reports whether a content type matches one of the MIME types
+func mimeTypeMatchesAny(contentType string, mimeTypes []string) bool {
+	for _, mimeType := range mimeTypes {
+		if mimeTypesMatch(contentType, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// mimeTypesMatch compares two media types without their parameters, honoring wildcards
+func mimeTypesMatch(a, b string) bool {
+	a, b = normalizeMimeType(a), normalizeMimeType(b)
+	if a == b || a == "*/*" || b == "*/*" {
+		return true
+	}
+
+	aType, aSubtype, _ := strings.Cut(a, "/")
+	bType, bSubtype, _ := strings.Cut(b, "/")
+	return aType == bType && (aSubtype == "*" || bSubtype == "*")
+}
+
+// normalizeMimeType strips media type parameters and lowercases the type
+func normalizeMimeType(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
 // resolveSchemaRefsRecursively resolves all schema references recursively
 func resolveSchemaRefsRecursively(
 	doc *openapi3.T,
@@ -596,7 +1163,7 @@ func resolveSchemaRefsRecursively(
 
 	// If this schema itself references another schema
 	if schema.Ref != "" {
-		refName, err := validateRef(schema.Ref, createLocation(fmt.Sprintf("schema.%s", schemaName)))
+		refName, err := validateComponentRef(schema.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s", schemaName)))
 		if err != nil {
 			return fmt.Errorf("%w (in schema %s)", err, schemaName)
 		}
@@ -634,7 +1201,7 @@ func processSchemaItems(doc *openapi3.T, filtered *openapi3.T, schema *openapi3.
 	}
 
 	if schema.Value.Items.Ref != "" {
-		refName, err := validateRef(schema.Value.Items.Ref, createLocation(fmt.Sprintf("schema.%s.items", schemaName)))
+		refName, err := validateComponentRef(schema.Value.Items.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.items", schemaName)))
 		if err != nil {
 			return fmt.Errorf("%w (in schema %s.items)", err, schemaName)
 		}
@@ -656,7 +1223,7 @@ func processSchemaItems(doc *openapi3.T, filtered *openapi3.T, schema *openapi3.
 func processItemProperties(doc *openapi3.T, filtered *openapi3.T, schema *openapi3.SchemaRef, schemaName string, processedRefs map[string]bool) error {
 	for propName, propSchema := range schema.Value.Items.Value.Properties {
 		if propSchema.Ref != "" {
-			refName, err := validateRef(propSchema.Ref, createLocation(fmt.Sprintf("schema.%s.items.properties.%s", schemaName, propName)))
+			refName, err := validateComponentRef(propSchema.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.items.properties.%s", schemaName, propName)))
 			if err != nil {
 				return fmt.Errorf("%w (in schema %s.items.properties.%s)", err, schemaName, propName)
 			}
@@ -669,7 +1236,7 @@ func processItemProperties(doc *openapi3.T, filtered *openapi3.T, schema *openap
 
 		// Process nested items within item properties
 		if propSchema.Value != nil && propSchema.Value.Items != nil && propSchema.Value.Items.Ref != "" {
-			refName, err := validateRef(propSchema.Value.Items.Ref, createLocation(fmt.Sprintf("schema.%s.items.properties.%s.items", schemaName, propName)))
+			refName, err := validateComponentRef(propSchema.Value.Items.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.items.properties.%s.items", schemaName, propName)))
 			if err != nil {
 				return fmt.Errorf("%w (in schema %s.items.properties.%s.items)",
 					err, schemaName, propName)
@@ -705,7 +1272,7 @@ func processSchemaProperties(doc *openapi3.T, filtered *openapi3.T, schema *open
 // processPropertyRef processes a property reference
 func processPropertyRef(doc *openapi3.T, filtered *openapi3.T, propSchema *openapi3.SchemaRef, schemaName, propName string, processedRefs map[string]bool) error {
 	if propSchema.Ref != "" {
-		refName, err := validateRef(propSchema.Ref, createLocation(fmt.Sprintf("schema.%s.properties.%s", schemaName, propName)))
+		refName, err := validateComponentRef(propSchema.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.properties.%s", schemaName, propName)))
 		if err != nil {
 			return fmt.Errorf("%w (in schema %s.properties.%s)", err, schemaName, propName)
 		}
@@ -725,7 +1292,7 @@ func processNestedPropertyObjects(doc *openapi3.T, filtered *openapi3.T, propSch
 
 	// Handle arrays of objects in properties
 	if propSchema.Value.Items != nil && propSchema.Value.Items.Ref != "" {
-		refName, err := validateRef(propSchema.Value.Items.Ref, createLocation(fmt.Sprintf("schema.%s.properties.%s.items", schemaName, propName)))
+		refName, err := validateComponentRef(propSchema.Value.Items.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.properties.%s.items", schemaName, propName)))
 		if err != nil {
 			return fmt.Errorf("%w (in schema %s.properties.%s.items)", err, schemaName, propName)
 		}
@@ -748,7 +1315,7 @@ func processNestedPropertyObjects(doc *openapi3.T, filtered *openapi3.T, propSch
 func processNestedProperties(doc *openapi3.T, filtered *openapi3.T, propSchema *openapi3.SchemaRef, schemaName, propName string, processedRefs map[string]bool) error {
 	for nestedPropName, nestedProp := range propSchema.Value.Properties {
 		if nestedProp.Ref != "" {
-			refName, err := validateRef(nestedProp.Ref, createLocation(fmt.Sprintf("schema.%s.properties.%s.%s", schemaName, propName, nestedPropName)))
+			refName, err := validateComponentRef(nestedProp.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.properties.%s.%s", schemaName, propName, nestedPropName)))
 			if err != nil {
 				return fmt.Errorf("%w (in schema %s.properties.%s.%s)",
 					err, schemaName, propName, nestedPropName)
@@ -762,7 +1329,7 @@ func processNestedProperties(doc *openapi3.T, filtered *openapi3.T, propSchema *
 
 		// Process even deeper nested items if they exist
 		if nestedProp.Value != nil && nestedProp.Value.Items != nil && nestedProp.Value.Items.Ref != "" {
-			refName, err := validateRef(nestedProp.Value.Items.Ref, createLocation(fmt.Sprintf("schema.%s.properties.%s.%s.items", schemaName, propName, nestedPropName)))
+			refName, err := validateComponentRef(nestedProp.Value.Items.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.properties.%s.%s.items", schemaName, propName, nestedPropName)))
 			if err != nil {
 				return fmt.Errorf("%w (in schema %s.properties.%s.%s.items)",
 					err, schemaName, propName, nestedPropName)
@@ -791,7 +1358,7 @@ func processCompositionSchemas(doc *openapi3.T, filtered *openapi3.T, schema *op
 	for _, compType := range compositionTypes {
 		for i, compositionSchema := range compType.schemas {
 			if compositionSchema.Ref != "" {
-				refName, err := validateRef(compositionSchema.Ref, createLocation(fmt.Sprintf("schema.%s.%s[%d]", schemaName, compType.name, i)))
+				refName, err := validateComponentRef(compositionSchema.Ref, ComponentSchemas, createLocation(fmt.Sprintf("schema.%s.%s[%d]", schemaName, compType.name, i)))
 				if err != nil {
 					return fmt.Errorf("%w (in schema %s.%s[%d])", err, schemaName, compType.name, i)
 				}
@@ -800,6 +1367,19 @@ func processCompositionSchemas(doc *openapi3.T, filtered *openapi3.T, schema *op
 					fmt.Sprintf("%s.%s[%d]", schemaName, compType.name, i)); err != nil {
 					return err
 				}
+			} else if compositionSchema.Value != nil {
+				// Inline members may reference schemas from their own properties or
+				// nested composition
+				refs := make(map[string]bool)
+				if err := extractSchemaValueReferences(compositionSchema.Value, refs); err != nil {
+					return fmt.Errorf("%w (in schema %s.%s[%d])", err, schemaName, compType.name, i)
+				}
+				for _, refName := range sortedKeys(refs) {
+					if err := resolveSchemaRefsRecursively(doc, filtered, refName, processedRefs,
+						fmt.Sprintf("%s.%s[%d]", schemaName, compType.name, i)); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -890,7 +1470,7 @@ func extractSchemaReferences(schema *openapi3.SchemaRef, processedSchemaRefs map
 
 	// Direct reference
 	if schema.Ref != "" {
-		schemaName, err := validateRef(schema.Ref, createLocation("schema.ref"))
+		schemaName, err := validateComponentRef(schema.Ref, ComponentSchemas, createLocation("schema.ref"))
 		if err != nil {
 			return err
 		}
@@ -116,6 +116,34 @@ func BenchmarkDeepSchemaTraversal(b *testing.B) {
 	}
 }
 
+// BenchmarkTransitiveUsage_Index benchmarks indexed transitive component discovery on 500 chained schemas
+func BenchmarkTransitiveUsage_Index(b *testing.B) {
+	benchmarkTransitiveUsage(b, findTransitivelyUsedComponents)
+}
+
+// BenchmarkTransitiveUsage_FixedPoint benchmarks the iterate-until-stable approach for comparison
+func BenchmarkTransitiveUsage_FixedPoint(b *testing.B) {
+	benchmarkTransitiveUsage(b, findTransitivelyUsedComponentsFixedPoint)
+}
+
+func benchmarkTransitiveUsage(b *testing.B, find func(*openapi3.T, *ComponentUsage)) {
+	doc := createTestSpecWithSchemaChain(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		usage := &ComponentUsage{
+			Schemas:       map[string]bool{"Schema0": true},
+			Parameters:    map[string]bool{},
+			RequestBodies: map[string]bool{},
+			Responses:     map[string]bool{},
+		}
+		find(doc, usage)
+		if len(usage.Schemas) != 500 {
+			b.Fatalf("Expected 500 used schemas, got %d", len(usage.Schemas))
+		}
+	}
+}
+
 // Helper functions for creating test data
 
 func createTestAPISpec(numPaths, numOpsPerPath int) *openapi3.T {
@@ -90,6 +90,43 @@ func TestValidateRef(t *testing.T) {
 	}
 }
 
+func TestValidateComponentRefCategory(t *testing.T) {
+	name, err := validateComponentRef("#/components/schemas/User", ComponentSchemas, nil)
+	if err != nil || name != "User" {
+		t.Errorf("Expected User without error, got %q, %v", name, err)
+	}
+
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: "Category Error", Version: "1.0.0"},
+		Paths: openapi3.NewPaths(openapi3.WithPath("/users", &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().WithDescription("OK").WithJSONSchemaRef(
+						&openapi3.SchemaRef{Ref: "#/components/parameters/UserId"}),
+				})),
+			},
+		})),
+		Components: &openapi3.Components{
+			Parameters: openapi3.ParametersMap{
+				"UserId": {Value: openapi3.NewQueryParameter("userId")},
+			},
+		},
+	}
+
+	_, err = applyFilter(doc, FilterOptions{})
+	var invalidRef InvalidReferenceError
+	if !errors.As(err, &invalidRef) {
+		t.Fatalf("Expected InvalidReferenceError, got: %v", err)
+	}
+	if invalidRef.Ref != "#/components/parameters/UserId" {
+		t.Errorf("Expected the parameter ref, got %s", invalidRef.Ref)
+	}
+	if invalidRef.Reason != "expected a reference to schemas, not parameters" {
+		t.Errorf("Unexpected reason: %s", invalidRef.Reason)
+	}
+}
+
 func TestPathMatchesFilter(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -212,6 +249,39 @@ func TestFindAllMimeTypes(t *testing.T) {
 	}
 }
 
+func TestProcessContentSchemasMediaTypeMatching(t *testing.T) {
+	mimeTypes := []string{"application/json", "text/plain"}
+
+	content := openapi3.Content{
+		"application/json; charset=utf-8": &openapi3.MediaType{
+			Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/User"},
+		},
+		"image/*": &openapi3.MediaType{
+			Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Image"},
+		},
+		"*/*": &openapi3.MediaType{
+			Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Fallback"},
+		},
+		"text/*": &openapi3.MediaType{
+			Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Text"},
+		},
+	}
+
+	refs := make(map[string]bool)
+	if err := processContentSchemas(content, mimeTypes, refs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{"User", "Fallback", "Text"} {
+		if !refs[expected] {
+			t.Errorf("Expected %s reference to be collected", expected)
+		}
+	}
+	if refs["Image"] {
+		t.Error("image/* should not match any requested MIME type")
+	}
+}
+
 func TestExtractSchemaReferences(t *testing.T) {
 	refs := make(map[string]bool)
 
@@ -0,0 +1,100 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// keepCategories lists the component categories that can be force-included with Keep
+var keepCategories = []string{
+	ComponentSchemas,
+	ComponentParameters,
+	ComponentRequestBodies,
+	ComponentResponses,
+}
+
+// validateKeepCategories rejects Keep entries for unsupported component categories
+func validateKeepCategories(keep map[string][]string) error {
+	for category := range keep {
+		if !slices.Contains(keepCategories, category) {
+			return fmt.Errorf("unknown component category %q (supported: %s)", category, strings.Join(keepCategories, ", "))
+		}
+	}
+	return nil
+}
+
+// anyMimeType matches every content type, so a kept component's content is walked
+// whole instead of through the media types the operations use
+var anyMimeType = []string{"*/*"}
+
+// collectKeptComponents marks the allowlisted components and the schemas they
+// reference as used, so they are resolved and survive pruning
+func collectKeptComponents(doc *openapi3.T, keep map[string][]string, processedRefs *ProcessedRefs) error {
+	if len(keep) == 0 {
+		return nil
+	}
+	components := doc.Components
+	if components == nil {
+		components = &openapi3.Components{}
+	}
+
+	for _, name := range keep[ComponentSchemas] {
+		if _, ok := components.Schemas[name]; !ok {
+			return &ComponentNotFoundError{Name: name, Type: "schema", Context: "keep list"}
+		}
+		processedRefs.Schemas[name] = true
+	}
+
+	for _, name := range keep[ComponentParameters] {
+		param, ok := components.Parameters[name]
+		if !ok {
+			return &ComponentNotFoundError{Name: name, Type: "parameter", Context: "keep list"}
+		}
+		processedRefs.Parameters[name] = true
+
+		if param.Value == nil {
+			continue
+		}
+		if param.Value.Schema != nil {
+			if err := extractSchemaReferences(param.Value.Schema, processedRefs.Schemas); err != nil {
+				return err
+			}
+		}
+		if err := processContentSchemas(param.Value.Content, anyMimeType, processedRefs.Schemas); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range keep[ComponentRequestBodies] {
+		requestBody, ok := components.RequestBodies[name]
+		if !ok {
+			return &ComponentNotFoundError{Name: name, Type: "request body", Context: "keep list"}
+		}
+		processedRefs.RequestBodies[name] = true
+
+		if requestBody.Value != nil {
+			if err := processContentSchemas(requestBody.Value.Content, anyMimeType, processedRefs.Schemas); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range keep[ComponentResponses] {
+		response, ok := components.Responses[name]
+		if !ok {
+			return &ComponentNotFoundError{Name: name, Type: "response", Context: "keep list"}
+		}
+		processedRefs.Responses[name] = true
+
+		if response.Value != nil {
+			if err := processContentSchemas(response.Value.Content, anyMimeType, processedRefs.Schemas); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package openax
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxFollowLinksDepth caps how many hops of response links are followed when
+// FilterOptions.FollowLinks is enabled.
+const maxFollowLinksDepth = 10
+
+// indexedOperation locates an operation within a document
+type indexedOperation struct {
+	Path      string
+	Method    string
+	Operation *openapi3.Operation
+}
+
+// indexOperationsByID maps every operationId in the document to its location
+func indexOperationsByID(doc *openapi3.T) map[string]indexedOperation {
+	index := make(map[string]indexedOperation)
+	if doc.Paths == nil {
+		return index
+	}
+
+	for path, pathItem := range doc.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		for method, operation := range pathItem.Operations() {
+			if operation != nil && operation.OperationID != "" {
+				index[operation.OperationID] = indexedOperation{Path: path, Method: method, Operation: operation}
+			}
+		}
+	}
+	return index
+}
+
+// followOperationLinks adds operations targeted by the links of retained responses,
+// following newly added operations transitively up to maxFollowLinksDepth hops.
+func followOperationLinks(doc *openapi3.T, filtered *openapi3.T, mimeTypes []string, usedTagNames map[string]bool, processedRefs *ProcessedRefs, report *FilterReport) error {
+	index := indexOperationsByID(doc)
+	retained := make(map[*openapi3.Operation]bool)
+
+	var frontier []*openapi3.Operation
+	for _, pathItem := range filtered.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			if operation != nil && !retained[operation] {
+				retained[operation] = true
+				frontier = append(frontier, operation)
+			}
+		}
+	}
+
+	for depth := 0; depth < maxFollowLinksDepth && len(frontier) > 0; depth++ {
+		var next []*openapi3.Operation
+
+		for _, operation := range frontier {
+			for _, operationID := range linkedOperationIDs(doc, operation) {
+				target, ok := index[operationID]
+				if !ok || retained[target.Operation] {
+					continue
+				}
+				retained[target.Operation] = true
+				next = append(next, target.Operation)
+
+				pathItem := filtered.Paths.Value(target.Path)
+				if pathItem == nil {
+					source, err := resolvePathItem(doc, target.Path, doc.Paths.Value(target.Path))
+					if err != nil {
+						return err
+					}
+					pathItem = &openapi3.PathItem{Parameters: source.Parameters}
+					filtered.Paths.Set(target.Path, pathItem)
+					if err := collectPathParameters(doc, pathItem, processedRefs); err != nil {
+						return err
+					}
+				}
+				pathItem.SetOperation(target.Method, target.Operation)
+
+				err := collectReferencesFromOperation(doc, target.Operation, mimeTypes,
+					processedRefs.Schemas, processedRefs.RequestBodies,
+					processedRefs.Parameters, processedRefs.Responses)
+				if err != nil {
+					return err
+				}
+
+				collectOperationTags(doc, target.Path, target.Method, target.Operation, usedTagNames, report)
+			}
+		}
+
+		frontier = next
+	}
+
+	return nil
+}
+
+// linkedOperationIDs returns the operationIds targeted by the links of an operation's responses
+func linkedOperationIDs(doc *openapi3.T, operation *openapi3.Operation) []string {
+	var operationIDs []string
+	if operation.Responses == nil {
+		return operationIDs
+	}
+
+	for _, responseRef := range operation.Responses.Map() {
+		response := resolveResponse(doc, responseRef)
+		if response == nil {
+			continue
+		}
+		for _, linkRef := range response.Links {
+			if link := resolveLink(doc, linkRef); link != nil && link.OperationID != "" {
+				operationIDs = append(operationIDs, link.OperationID)
+			}
+		}
+	}
+	return operationIDs
+}
+
+// resolveResponse returns the response value, looking up component references when
+// the loader has not populated the value
+func resolveResponse(doc *openapi3.T, responseRef *openapi3.ResponseRef) *openapi3.Response {
+	if responseRef == nil {
+		return nil
+	}
+	if responseRef.Value != nil {
+		return responseRef.Value
+	}
+	if responseRef.Ref != "" && doc.Components != nil {
+		if component, ok := doc.Components.Responses[extractRefName(responseRef.Ref)]; ok && component != nil {
+			return component.Value
+		}
+	}
+	return nil
+}
+
+// resolveLink returns the link value, looking up component references when
+// the loader has not populated the value
+func resolveLink(doc *openapi3.T, linkRef *openapi3.LinkRef) *openapi3.Link {
+	if linkRef == nil {
+		return nil
+	}
+	if linkRef.Value != nil {
+		return linkRef.Value
+	}
+	if linkRef.Ref != "" && doc.Components != nil {
+		if component, ok := doc.Components.Links[extractRefName(linkRef.Ref)]; ok && component != nil {
+			return component.Value
+		}
+	}
+	return nil
+}
+
+// pruneDanglingLinks removes the response links of the filtered spec whose
+// operationId or local operationRef targets an operation that was not retained,
+// reporting each removal as a warning. Component schemas referenced from the
+// parameters or requestBody of a retained link are kept.
+func pruneDanglingLinks(doc *openapi3.T, filtered *openapi3.T, processedRefs *ProcessedRefs, report *FilterReport) error {
+	retainedIDs := make(map[string]bool)
+	retainedOps := make(map[string]bool)
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		pathItem := filtered.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for method, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			retainedOps[strings.ToLower(method)+" "+path] = true
+			if operation.OperationID != "" {
+				retainedIDs[operation.OperationID] = true
+			}
+		}
+	}
+
+	var kept []string
+	links := func(response *openapi3.ResponseRef, at string) *openapi3.ResponseRef {
+		// Referenced responses are checked where they are defined
+		if response == nil || response.Ref != "" || response.Value == nil || len(response.Value.Links) == 0 {
+			return response
+		}
+
+		var pruned openapi3.Links
+		for _, name := range sortedKeys(response.Value.Links) {
+			link := resolveLink(doc, response.Value.Links[name])
+			if link == nil {
+				continue
+			}
+			if target, ok := danglingLinkTarget(link, retainedIDs, retainedOps); ok {
+				report.warn(createLocation(at+".links."+name), "link %q targets %s, which is not in the filtered spec; removed", name, target)
+				if pruned == nil {
+					pruned = maps.Clone(response.Value.Links)
+				}
+				delete(pruned, name)
+				continue
+			}
+			kept = append(kept, linkSchemaRefs(link)...)
+		}
+		if pruned == nil {
+			return response
+		}
+
+		value := *response.Value
+		value.Links = pruned
+		copied := *response
+		copied.Value = &value
+		return &copied
+	}
+
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		pathItem := filtered.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		var copied *openapi3.PathItem
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil || operation.Responses == nil {
+				continue
+			}
+			at := fmt.Sprintf("paths.%s.%s.responses", path, strings.ToLower(method))
+
+			var responses *openapi3.Responses
+			for _, status := range sortedKeys(operation.Responses.Map()) {
+				response := operation.Responses.Value(status)
+				checked := links(response, at+"."+status)
+				if checked == response {
+					continue
+				}
+				if responses == nil {
+					responses = &openapi3.Responses{Extensions: operation.Responses.Extensions}
+					for status, response := range operation.Responses.Map() {
+						responses.Set(status, response)
+					}
+				}
+				responses.Set(status, checked)
+			}
+			if responses == nil {
+				continue
+			}
+
+			if copied == nil {
+				item := *pathItem
+				copied = &item
+			}
+			op := *operation
+			op.Responses = responses
+			copied.SetOperation(method, &op)
+		}
+		if copied != nil {
+			filtered.Paths.Set(path, copied)
+		}
+	}
+
+	if filtered.Components != nil {
+		for _, name := range sortedKeys(filtered.Components.Responses) {
+			filtered.Components.Responses[name] = links(filtered.Components.Responses[name], "components.responses."+name)
+		}
+	}
+
+	for _, name := range kept {
+		if processedRefs.Schemas[name] || doc.Components == nil || doc.Components.Schemas[name] == nil {
+			continue
+		}
+		processedRefs.Schemas[name] = true
+		if err := resolveSchemaRefsRecursively(doc, filtered, name, make(map[string]bool), "components.schemas."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// danglingLinkTarget describes the target of a link that points to an operation
+// missing from the retained set. Links to operations in other documents are not
+// checked.
+func danglingLinkTarget(link *openapi3.Link, retainedIDs, retainedOps map[string]bool) (string, bool) {
+	if link.OperationID != "" {
+		return fmt.Sprintf("operationId %q", link.OperationID), !retainedIDs[link.OperationID]
+	}
+
+	pointer, ok := strings.CutPrefix(link.OperationRef, "#/paths/")
+	if !ok {
+		return "", false
+	}
+	escapedPath, method, ok := strings.Cut(pointer, "/")
+	if !ok {
+		return "", false
+	}
+	path, err := url.PathUnescape(escapedPath)
+	if err != nil {
+		path = escapedPath
+	}
+	path = strings.NewReplacer("~1", "/", "~0", "~").Replace(path)
+	return fmt.Sprintf("operationRef %q", link.OperationRef), !retainedOps[strings.ToLower(method)+" "+path]
+}
+
+// linkSchemaRefs returns the names of the component schemas referenced with $ref
+// from the literal parameters and requestBody of a link
+func linkSchemaRefs(link *openapi3.Link) []string {
+	var names []string
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			if ref, ok := value["$ref"].(string); ok {
+				if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+					names = append(names, name)
+				}
+			}
+			for _, key := range sortedKeys(value) {
+				walk(value[key])
+			}
+		case []any:
+			for _, item := range value {
+				walk(item)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(link.Parameters) {
+		walk(link.Parameters[name])
+	}
+	walk(link.RequestBody)
+	return names
+}
@@ -0,0 +1,226 @@
+package openax
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowLinks(t *testing.T) {
+	t.Run("linked operation is pulled in", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Operations:  []string{"getPet"},
+			FollowLinks: true,
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, filteredDoc.Paths.Value("/pets/{id}"))
+		require.NotNil(t, filteredDoc.Paths.Value("/owners/{id}"))
+		assert.NotNil(t, filteredDoc.Paths.Value("/owners/{id}").Get)
+		assert.Nil(t, filteredDoc.Paths.Value("/stores"))
+		assert.Contains(t, filteredDoc.Components.Schemas, "Owner")
+	})
+
+	t.Run("links are followed transitively", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Operations:  []string{"listPets"},
+			FollowLinks: true,
+		})
+		require.NoError(t, err)
+
+		assert.NotNil(t, filteredDoc.Paths.Value("/pets/{id}"))
+		assert.NotNil(t, filteredDoc.Paths.Value("/owners/{id}"))
+	})
+
+	t.Run("linked operation keeps path-level parameters", func(t *testing.T) {
+		client := New()
+		doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        "200":
+          description: OK
+          links:
+            GetPet:
+              operationId: getPet
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: getPet
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    PetID:
+      name: id
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/PetIDValue'
+  schemas:
+    PetIDValue:
+      type: string
+`))
+		require.NoError(t, err)
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Tags:            []string{"pets"},
+			FollowLinks:     true,
+			PruneComponents: true,
+		})
+		require.NoError(t, err)
+
+		pathItem := filteredDoc.Paths.Value("/pets/{id}")
+		require.NotNil(t, pathItem)
+		require.Len(t, pathItem.Parameters, 1)
+		assert.Equal(t, "#/components/parameters/PetID", pathItem.Parameters[0].Ref)
+		assert.Contains(t, filteredDoc.Components.Parameters, "PetID")
+		assert.Contains(t, filteredDoc.Components.Schemas, "PetIDValue")
+		require.NoError(t, client.Validate(filteredDoc))
+	})
+
+	t.Run("links are not followed by default", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Operations: []string{"getPet"},
+		})
+		require.NoError(t, err)
+
+		assert.NotNil(t, filteredDoc.Paths.Value("/pets/{id}"))
+		assert.Nil(t, filteredDoc.Paths.Value("/owners/{id}"))
+		assert.NotContains(t, filteredDoc.Components.Schemas, "Owner")
+	})
+}
+
+func TestPruneDanglingLinks(t *testing.T) {
+	t.Run("link to a dropped operation is removed", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+		report := &FilterReport{}
+
+		filteredDoc, err := applyFilterWithReport(doc, FilterOptions{
+			Operations: []string{"listPets", "getPet"},
+		}, report)
+		require.NoError(t, err)
+
+		kept := filteredDoc.Paths.Value("/pets").Get.Responses.Value("200").Value.Links
+		assert.Contains(t, kept, "pet")
+
+		pruned := filteredDoc.Paths.Value("/pets/{id}").Get.Responses.Value("200").Value.Links
+		assert.NotContains(t, pruned, "owner")
+		require.Len(t, report.Warnings, 1)
+		assert.Contains(t, report.Warnings[0].Message, `operationId "getOwner"`)
+		assert.Equal(t, "paths./pets/{id}.get.responses.200.links.owner", report.Warnings[0].Location.Path)
+
+		// The source document keeps its link
+		assert.Contains(t, doc.Paths.Value("/pets/{id}").Get.Responses.Value("200").Value.Links, "owner")
+	})
+
+	t.Run("operationRef is checked against retained operations", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+		links := doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Links
+		links["byRef"] = &openapi3.LinkRef{Value: &openapi3.Link{OperationRef: "#/paths/~1stores/get"}}
+		links["body"] = &openapi3.LinkRef{Value: &openapi3.Link{
+			OperationRef: "#/paths/~1pets~1{id}/get",
+			RequestBody:  map[string]any{"$ref": "#/components/schemas/Owner"},
+		}}
+		report := &FilterReport{}
+
+		filteredDoc, err := applyFilterWithReport(doc, FilterOptions{
+			Operations: []string{"listPets", "getPet"},
+		}, report)
+		require.NoError(t, err)
+
+		kept := filteredDoc.Paths.Value("/pets").Get.Responses.Value("200").Value.Links
+		assert.Contains(t, kept, "body")
+		assert.NotContains(t, kept, "byRef")
+		assert.Contains(t, filteredDoc.Components.Schemas, "Owner")
+	})
+
+	t.Run("followed links are kept", func(t *testing.T) {
+		doc := createTestSpecWithLinks()
+		report := &FilterReport{}
+
+		filteredDoc, err := applyFilterWithReport(doc, FilterOptions{
+			Operations:  []string{"getPet"},
+			FollowLinks: true,
+		}, report)
+		require.NoError(t, err)
+
+		assert.Contains(t, filteredDoc.Paths.Value("/pets/{id}").Get.Responses.Value("200").Value.Links, "owner")
+		assert.Empty(t, report.Warnings)
+	})
+}
+
+func createTestSpecWithLinks() *openapi3.T {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "Test API",
+			Version: "1.0.0",
+		},
+		Paths: &openapi3.Paths{},
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"Pet": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{Type: &openapi3.Types{"object"}},
+				},
+				"Owner": &openapi3.SchemaRef{
+					Value: &openapi3.Schema{Type: &openapi3.Types{"object"}},
+				},
+			},
+		},
+	}
+
+	newOperation := func(operationID, schemaName string, links openapi3.Links) *openapi3.Operation {
+		description := okDescription
+		operation := &openapi3.Operation{
+			OperationID: operationID,
+			Responses:   &openapi3.Responses{},
+		}
+		response := &openapi3.Response{Description: &description, Links: links}
+		if schemaName != "" {
+			response.Content = openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/" + schemaName},
+				},
+			}
+		}
+		operation.Responses.Set("200", &openapi3.ResponseRef{Value: response})
+		return operation
+	}
+
+	doc.Paths.Set("/pets", &openapi3.PathItem{
+		Get: newOperation("listPets", "Pet", openapi3.Links{
+			"pet": &openapi3.LinkRef{Value: &openapi3.Link{OperationID: "getPet"}},
+		}),
+	})
+	doc.Paths.Set("/pets/{id}", &openapi3.PathItem{
+		Get: newOperation("getPet", "Pet", openapi3.Links{
+			"owner": &openapi3.LinkRef{Value: &openapi3.Link{OperationID: "getOwner"}},
+		}),
+	})
+	doc.Paths.Set("/owners/{id}", &openapi3.PathItem{
+		Get: newOperation("getOwner", "Owner", nil),
+	})
+	doc.Paths.Set("/stores", &openapi3.PathItem{
+		Get: newOperation("listStores", "", nil),
+	})
+
+	return doc
+}
@@ -0,0 +1,190 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Lint rule identifiers reported in LintFinding.Rule.
+const (
+	// RuleResponseSchema flags response media types that declare no schema.
+	RuleResponseSchema = "response-schema"
+
+	// RuleRequiredProperties flags required entries that name undefined properties.
+	RuleRequiredProperties = "required-properties"
+
+	// RuleUnusedComponents flags components that no operation references.
+	RuleUnusedComponents = "unused-components"
+
+	// RuleDuplicatePaths flags paths that differ only in path parameter names.
+	RuleDuplicatePaths = "duplicate-paths"
+
+	// RuleServerURL flags servers with empty or relative URLs.
+	RuleServerURL = "server-url"
+
+	// RuleRequestBodyRequired flags request bodies not marked required.
+	RuleRequestBodyRequired = "request-body-required"
+)
+
+// Severity indicates how serious a lint finding is.
+type Severity string
+
+// Supported severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// LintOptions selects which lint rules are run.
+//
+// All rules are disabled by default.
+type LintOptions struct {
+	// CheckResponseSchemas reports response media types without a schema.
+	// Media types carrying only an example are still reported.
+	CheckResponseSchemas bool
+
+	// CheckRequired reports object schemas listing a property in `required` that is
+	// not defined in `properties`, taking allOf/oneOf/anyOf composition into account.
+	CheckRequired bool
+
+	// CheckUnusedComponents reports schemas, parameters, request bodies, and responses
+	// in components that are not referenced anywhere in the document.
+	CheckUnusedComponents bool
+
+	// CheckDuplicatePaths reports paths that are equivalent once path parameter
+	// names are ignored, such as /users/{id} and /users/{userId}.
+	CheckDuplicatePaths bool
+
+	// CheckServers reports servers whose URL is empty or not absolute.
+	// The root URL "/" is allowed.
+	CheckServers bool
+
+	// CheckRequestBodyRequired reports operation request bodies that are not marked
+	// `required: true`. An absent `required` cannot be told apart from an explicit
+	// `required: false`, so both are reported.
+	CheckRequestBodyRequired bool
+}
+
+// LintFinding describes a single issue reported by Lint.
+type LintFinding struct {
+	Rule     string          // Identifier of the rule that produced the finding
+	Severity Severity        // How serious the issue is
+	Message  string          // Human-readable description of the issue
+	Location *SourceLocation // Location of the issue in the specification
+}
+
+// String returns a human-readable representation of the finding.
+func (f LintFinding) String() string {
+	if f.Location == nil {
+		return fmt.Sprintf("[%s] %s", f.Rule, f.Message)
+	}
+	return fmt.Sprintf("[%s] %s at %s", f.Rule, f.Message, f.Location.String())
+}
+
+// Lint checks an OpenAPI specification for quality issues that are not covered
+// by structural validation.
+//
+// Rules added with RegisterRule run after the rules selected by opts.
+// Findings are returned in a stable order. An empty result means no issues were found.
+//
+// Example:
+//
+//	findings := client.Lint(doc, openax.LintOptions{CheckResponseSchemas: true})
+//	for _, finding := range findings {
+//		fmt.Println(finding)
+//	}
+func (c *Client) Lint(doc *openapi3.T, opts LintOptions) []LintFinding {
+	return lintSpec(doc, opts)
+}
+
+// lintSpec runs the enabled lint rules against a document
+func lintSpec(doc *openapi3.T, opts LintOptions) []LintFinding {
+	var findings []LintFinding
+
+	if opts.CheckResponseSchemas {
+		findings = append(findings, lintResponseSchemas(doc)...)
+	}
+
+	if opts.CheckRequired {
+		findings = append(findings, lintRequiredProperties(doc)...)
+	}
+
+	if opts.CheckUnusedComponents {
+		findings = append(findings, lintUnusedComponents(doc)...)
+	}
+
+	if opts.CheckDuplicatePaths && doc.Paths != nil {
+		findings = append(findings, lintDuplicatePaths(doc.Paths.InMatchingOrder())...)
+	}
+
+	if opts.CheckServers {
+		findings = append(findings, lintServers(doc)...)
+	}
+
+	if opts.CheckRequestBodyRequired {
+		findings = append(findings, lintRequestBodyRequired(doc)...)
+	}
+
+	return append(findings, runRegisteredRules(doc)...)
+}
+
+// lintResponseSchemas reports every response media type that lacks a schema
+func lintResponseSchemas(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil || operation.Responses == nil {
+				continue
+			}
+
+			responses := operation.Responses.Map()
+			for _, status := range sortedKeys(responses) {
+				response := resolveResponse(doc, responses[status])
+				if response == nil {
+					continue
+				}
+
+				for _, mediaTypeName := range sortedKeys(response.Content) {
+					mediaType := response.Content[mediaTypeName]
+					if mediaType != nil && mediaType.Schema != nil {
+						continue
+					}
+
+					findings = append(findings, LintFinding{
+						Rule:     RuleResponseSchema,
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("response %s of %s %s declares %s without a schema", status, strings.ToUpper(method), path, mediaTypeName),
+						Location: createLocation(fmt.Sprintf("paths.%s.%s.responses.%s.content.%s",
+							path, strings.ToLower(method), status, mediaTypeName)),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
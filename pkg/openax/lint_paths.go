@@ -0,0 +1,47 @@
+package openax
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// pathParameterPattern matches a templated path parameter such as {id}
+var pathParameterPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// lintDuplicatePaths reports paths that differ only in path parameter names and
+// therefore collide at routing time (e.g., /users/{id} and /users/{userId})
+func lintDuplicatePaths(paths []string) []LintFinding {
+	var findings []LintFinding
+
+	groups := make(map[string][]string)
+	for _, path := range paths {
+		normalized := normalizePathTemplate(path)
+		groups[normalized] = append(groups[normalized], path)
+	}
+
+	for _, normalized := range sortedKeys(groups) {
+		group := groups[normalized]
+		if len(group) < 2 {
+			continue
+		}
+		slices.Sort(group)
+
+		// Report every later path against the first one in the group
+		for _, path := range group[1:] {
+			findings = append(findings, LintFinding{
+				Rule:     RuleDuplicatePaths,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("path %s collides with %s", path, group[0]),
+				Location: createLocation("paths." + path),
+			})
+		}
+	}
+
+	return findings
+}
+
+// normalizePathTemplate replaces path parameter names with positional placeholders
+func normalizePathTemplate(path string) string {
+	return pathParameterPattern.ReplaceAllString(path, "{}")
+}
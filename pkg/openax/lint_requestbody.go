@@ -0,0 +1,45 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// lintRequestBodyRequired reports operation request bodies that are not marked
+// required. kin-openapi decodes an absent `required` as false, so bodies that are
+// explicitly optional are reported as well; the message asks to confirm either way.
+func lintRequestBodyRequired(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil {
+				continue
+			}
+			if operation.RequestBody.Value.Required {
+				continue
+			}
+
+			findings = append(findings, LintFinding{
+				Rule:     RuleRequestBodyRequired,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("request body of %s %s is not marked required; set required: true if clients must send it", strings.ToUpper(method), path),
+				Location: createLocation(fmt.Sprintf("paths.%s.%s.requestBody", path, strings.ToLower(method))),
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,164 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// lintRequiredProperties reports required entries that do not resolve to a defined
+// property in component schemas and inline operation schemas
+func lintRequiredProperties(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	check := func(schema *openapi3.SchemaRef, location string) {
+		findings = append(findings, checkRequiredProperties(doc, schema, location)...)
+	}
+
+	if doc.Components != nil {
+		for _, name := range sortedKeys(doc.Components.Schemas) {
+			check(doc.Components.Schemas[name], "components.schemas."+name)
+		}
+	}
+
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil {
+				continue
+			}
+			base := fmt.Sprintf("paths.%s.%s", path, strings.ToLower(method))
+
+			if operation.RequestBody != nil && operation.RequestBody.Ref == "" && operation.RequestBody.Value != nil {
+				for _, mediaType := range sortedKeys(operation.RequestBody.Value.Content) {
+					check(operation.RequestBody.Value.Content[mediaType].Schema, base+".requestBody.content."+mediaType+".schema")
+				}
+			}
+
+			if operation.Responses == nil {
+				continue
+			}
+			responses := operation.Responses.Map()
+			for _, status := range sortedKeys(responses) {
+				response := responses[status]
+				if response == nil || response.Ref != "" || response.Value == nil {
+					continue
+				}
+				for _, mediaType := range sortedKeys(response.Value.Content) {
+					check(response.Value.Content[mediaType].Schema,
+						fmt.Sprintf("%s.responses.%s.content.%s.schema", base, status, mediaType))
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// checkRequiredProperties checks a schema and its nested inline schemas. References
+// are not followed, since referenced schemas are checked where they are defined.
+func checkRequiredProperties(doc *openapi3.T, ref *openapi3.SchemaRef, location string) []LintFinding {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return nil
+	}
+	schema := ref.Value
+
+	var findings []LintFinding
+	defined := collectDefinedProperties(doc, schema, make(map[*openapi3.Schema]bool))
+	for _, name := range collectRequiredProperties(schema) {
+		if !defined[name] {
+			findings = append(findings, LintFinding{
+				Rule:     RuleRequiredProperties,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("required property %q is not defined in properties", name),
+				Location: createLocation(location),
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		findings = append(findings, checkRequiredProperties(doc, schema.Properties[name], location+".properties."+name)...)
+	}
+	findings = append(findings, checkRequiredProperties(doc, schema.Items, location+".items")...)
+	findings = append(findings, checkRequiredProperties(doc, schema.AdditionalProperties.Schema, location+".additionalProperties")...)
+
+	// Inline allOf members are checked as part of this schema; only their nested schemas are visited
+	for i, member := range schema.AllOf {
+		if member != nil && member.Ref == "" && member.Value != nil {
+			for _, name := range sortedKeys(member.Value.Properties) {
+				findings = append(findings, checkRequiredProperties(doc, member.Value.Properties[name],
+					fmt.Sprintf("%s.allOf[%d].properties.%s", location, i, name))...)
+			}
+		}
+	}
+	for i, member := range schema.OneOf {
+		findings = append(findings, checkRequiredProperties(doc, member, fmt.Sprintf("%s.oneOf[%d]", location, i))...)
+	}
+	for i, member := range schema.AnyOf {
+		findings = append(findings, checkRequiredProperties(doc, member, fmt.Sprintf("%s.anyOf[%d]", location, i))...)
+	}
+
+	return findings
+}
+
+// collectRequiredProperties returns the required names of a schema and its inline allOf members
+func collectRequiredProperties(schema *openapi3.Schema) []string {
+	required := append([]string(nil), schema.Required...)
+	for _, member := range schema.AllOf {
+		if member != nil && member.Ref == "" && member.Value != nil {
+			required = append(required, collectRequiredProperties(member.Value)...)
+		}
+	}
+	return required
+}
+
+// collectDefinedProperties returns the property names defined by a schema, including
+// those brought in through allOf, oneOf, and anyOf
+func collectDefinedProperties(doc *openapi3.T, schema *openapi3.Schema, visited map[*openapi3.Schema]bool) map[string]bool {
+	defined := make(map[string]bool)
+	if schema == nil || visited[schema] {
+		return defined
+	}
+	visited[schema] = true
+
+	for name := range schema.Properties {
+		defined[name] = true
+	}
+
+	for _, members := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, member := range members {
+			for name := range collectDefinedProperties(doc, resolveSchema(doc, member), visited) {
+				defined[name] = true
+			}
+		}
+	}
+
+	return defined
+}
+
+// resolveSchema returns the schema value, looking up component references when
+// the loader has not populated the value
+func resolveSchema(doc *openapi3.T, schemaRef *openapi3.SchemaRef) *openapi3.Schema {
+	if schemaRef == nil {
+		return nil
+	}
+	if schemaRef.Value != nil {
+		return schemaRef.Value
+	}
+	if schemaRef.Ref != "" && doc.Components != nil {
+		if component, ok := doc.Components.Schemas[extractRefName(schemaRef.Ref)]; ok && component != nil {
+			return component.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// LintRule is a custom lint check, e.g. for organization-specific conventions.
+// Findings should carry the rule's own identifier in LintFinding.Rule.
+type LintRule interface {
+	Check(doc *openapi3.T) []LintFinding
+}
+
+// LintRuleFunc adapts an ordinary function to the LintRule interface.
+type LintRuleFunc func(doc *openapi3.T) []LintFinding
+
+// Check calls f(doc).
+func (f LintRuleFunc) Check(doc *openapi3.T) []LintFinding {
+	return f(doc)
+}
+
+// Identifiers of the example rules below.
+const (
+	// RuleOperationID flags operations without an operationId.
+	RuleOperationID = "operation-id"
+
+	// RuleSuccessExample flags 2xx response media types without an example.
+	RuleSuccessExample = "success-example"
+)
+
+// Example rules, not run unless registered or passed to Lint.
+var (
+	// OperationIDRule reports operations that declare no operationId.
+	OperationIDRule LintRule = LintRuleFunc(lintOperationIDs)
+
+	// SuccessExampleRule reports 2xx response media types that have neither an
+	// example nor named examples, at the media type or schema level.
+	SuccessExampleRule LintRule = LintRuleFunc(lintSuccessExamples)
+)
+
+// registeredRule is a rule added with RegisterRule
+type registeredRule struct {
+	id   int
+	rule LintRule
+}
+
+var ruleRegistry struct {
+	sync.RWMutex
+	nextID int
+	rules  []registeredRule
+}
+
+// RegisterRule adds a custom rule that Lint and Client.Lint run after the built-in
+// rules. It is safe for concurrent use. The returned function unregisters the rule.
+//
+// Example:
+//
+//	unregister := openax.RegisterRule(openax.SuccessExampleRule)
+//	defer unregister()
+func RegisterRule(rule LintRule) (unregister func()) {
+	ruleRegistry.Lock()
+	defer ruleRegistry.Unlock()
+
+	id := ruleRegistry.nextID
+	ruleRegistry.nextID++
+	ruleRegistry.rules = append(ruleRegistry.rules, registeredRule{id: id, rule: rule})
+
+	return func() {
+		ruleRegistry.Lock()
+		defer ruleRegistry.Unlock()
+		for i, registered := range ruleRegistry.rules {
+			if registered.id == id {
+				ruleRegistry.rules = append(ruleRegistry.rules[:i:i], ruleRegistry.rules[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Lint runs the registered rules and then the given rules against a document.
+// Built-in rules selected by LintOptions are run by Client.Lint.
+//
+// Example:
+//
+//	findings := openax.Lint(doc, openax.OperationIDRule, myRule)
+func Lint(doc *openapi3.T, rules ...LintRule) []LintFinding {
+	findings := runRegisteredRules(doc)
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(doc)...)
+	}
+	return findings
+}
+
+// runRegisteredRules runs every registered rule in registration order
+func runRegisteredRules(doc *openapi3.T) []LintFinding {
+	ruleRegistry.RLock()
+	rules := make([]LintRule, 0, len(ruleRegistry.rules))
+	for _, registered := range ruleRegistry.rules {
+		rules = append(rules, registered.rule)
+	}
+	ruleRegistry.RUnlock()
+
+	var findings []LintFinding
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(doc)...)
+	}
+	return findings
+}
+
+// lintOperationIDs reports every operation without an operationId
+func lintOperationIDs(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			if operation := operations[method]; operation != nil && operation.OperationID == "" {
+				findings = append(findings, LintFinding{
+					Rule:     RuleOperationID,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("%s %s has no operationId", strings.ToUpper(method), path),
+					Location: createLocation(fmt.Sprintf("paths.%s.%s", path, strings.ToLower(method))),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// lintSuccessExamples reports every 2xx response media type without an example
+func lintSuccessExamples(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil || operation.Responses == nil {
+				continue
+			}
+
+			responses := operation.Responses.Map()
+			for _, status := range sortedKeys(responses) {
+				response := resolveResponse(doc, responses[status])
+				if !strings.HasPrefix(status, "2") || response == nil {
+					continue
+				}
+				for _, mediaTypeName := range sortedKeys(response.Content) {
+					if hasExample(response.Content[mediaTypeName]) {
+						continue
+					}
+					findings = append(findings, LintFinding{
+						Rule:     RuleSuccessExample,
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("response %s of %s %s has no example for %s", status, strings.ToUpper(method), path, mediaTypeName),
+						Location: createLocation(fmt.Sprintf("paths.%s.%s.responses.%s.content.%s",
+							path, strings.ToLower(method), status, mediaTypeName)),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// hasExample reports whether a media type or its schema carries an example
+func hasExample(mediaType *openapi3.MediaType) bool {
+	if mediaType == nil {
+		return false
+	}
+	if mediaType.Example != nil || len(mediaType.Examples) > 0 {
+		return true
+	}
+	return mediaType.Schema != nil && mediaType.Schema.Value != nil && mediaType.Schema.Value.Example != nil
+}
@@ -0,0 +1,67 @@
+package openax
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// lintServers reports servers whose URL is empty or relative, at the document,
+// path, and operation level. The root URL "/" is allowed.
+func lintServers(doc *openapi3.T) []LintFinding {
+	findings := checkServerURLs(doc.Servers, "servers")
+	if doc.Paths == nil {
+		return findings
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		findings = append(findings, checkServerURLs(pathItem.Servers, fmt.Sprintf("paths.%s.servers", path))...)
+
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if operation == nil || operation.Servers == nil {
+				continue
+			}
+			findings = append(findings, checkServerURLs(*operation.Servers,
+				fmt.Sprintf("paths.%s.%s.servers", path, strings.ToLower(method)))...)
+		}
+	}
+
+	return findings
+}
+
+// checkServerURLs reports each server in the list whose URL is not absolute
+func checkServerURLs(servers openapi3.Servers, location string) []LintFinding {
+	var findings []LintFinding
+	for i, server := range servers {
+		if server == nil || server.URL == "/" || isAbsoluteServerURL(server.URL) {
+			continue
+		}
+
+		message := fmt.Sprintf("server %d has a relative URL %q", i, server.URL)
+		if server.URL == "" {
+			message = fmt.Sprintf("server %d has an empty URL", i)
+		}
+		findings = append(findings, LintFinding{
+			Rule:     RuleServerURL,
+			Severity: SeverityError,
+			Message:  message,
+			Location: createLocation(fmt.Sprintf("%s.%d.url", location, i)),
+		})
+	}
+	return findings
+}
+
+// isAbsoluteServerURL reports whether a server URL has a scheme and host.
+// Server variables are replaced by a placeholder so templated hosts parse.
+func isAbsoluteServerURL(serverURL string) bool {
+	u, err := url.Parse(pathParameterPattern.ReplaceAllString(serverURL, "x"))
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
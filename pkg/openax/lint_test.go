@@ -0,0 +1,369 @@
+package openax_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintResponseSchemas(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              example: {"name": "Rex"}
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                type: object
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("rule disabled by default", func(t *testing.T) {
+		assert.Empty(t, client.Lint(doc, openax.LintOptions{}))
+	})
+
+	t.Run("reports media type without schema", func(t *testing.T) {
+		findings := client.Lint(doc, openax.LintOptions{CheckResponseSchemas: true})
+		require.Len(t, findings, 1)
+
+		assert.Equal(t, openax.RuleResponseSchema, findings[0].Rule)
+		require.NotNil(t, findings[0].Location)
+		assert.Equal(t, "paths./pets.get.responses.200.content.application/json", findings[0].Location.Path)
+	})
+}
+
+func TestLintRequiredProperties(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: integer
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [id, breed]
+          properties:
+            breed:
+              type: string
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	findings := client.Lint(doc, openax.LintOptions{CheckRequired: true})
+	require.Len(t, findings, 1)
+
+	assert.Equal(t, openax.RuleRequiredProperties, findings[0].Rule)
+	assert.Contains(t, findings[0].Message, `"name"`)
+	require.NotNil(t, findings[0].Location)
+	assert.Equal(t, "components.schemas.Pet", findings[0].Location.Path)
+}
+
+func TestLintUnusedComponents(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          $ref: '#/components/responses/PetResponse'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+  parameters:
+    PetID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+    Offset:
+      name: offset
+      in: query
+      schema:
+        type: integer
+  requestBodies:
+    PetBody:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  responses:
+    PetResponse:
+      description: OK
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+    NotFound:
+      description: Not found
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	findings := client.Lint(doc, openax.LintOptions{CheckUnusedComponents: true})
+
+	var locations []string
+	for _, finding := range findings {
+		assert.Equal(t, openax.RuleUnusedComponents, finding.Rule)
+		assert.Equal(t, openax.SeverityWarning, finding.Severity)
+		require.NotNil(t, finding.Location)
+		locations = append(locations, finding.Location.Path)
+	}
+
+	assert.Equal(t, []string{
+		"components.parameters.Offset",
+		"components.requestBodies.PetBody",
+		"components.responses.NotFound",
+	}, locations, "Only orphan components should be reported")
+}
+
+func TestLintDuplicatePaths(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+  /users/{userId}:
+    delete:
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+  /users/me:
+    get:
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	findings := client.Lint(doc, openax.LintOptions{CheckDuplicatePaths: true})
+	require.Len(t, findings, 1, "Only the parameterized paths collide")
+
+	assert.Equal(t, openax.RuleDuplicatePaths, findings[0].Rule)
+	assert.Equal(t, "path /users/{userId} collides with /users/{id}", findings[0].Message)
+	require.NotNil(t, findings[0].Location)
+	assert.Equal(t, "paths./users/{userId}", findings[0].Location.Path)
+}
+
+func TestLintServers(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+servers:
+  - url: https://{region}.api.example.com
+    variables:
+      region:
+        default: us
+  - url: ''
+  - url: /
+paths:
+  /pets:
+    get:
+      servers:
+        - url: api/v1
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	findings := client.Lint(doc, openax.LintOptions{CheckServers: true})
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, openax.RuleServerURL, findings[0].Rule)
+	assert.Equal(t, "server 1 has an empty URL", findings[0].Message)
+	require.NotNil(t, findings[0].Location)
+	assert.Equal(t, "servers.1.url", findings[0].Location.Path)
+
+	assert.Equal(t, `server 0 has a relative URL "api/v1"`, findings[1].Message)
+	require.NotNil(t, findings[1].Location)
+	assert.Equal(t, "paths./pets.get.servers.0.url", findings[1].Location.Path)
+}
+
+func TestLintCustomRules(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              example: [{"name": "Rex"}]
+    post:
+      operationId: create_pet
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	camelCase := regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	camelCaseRule := openax.LintRuleFunc(func(doc *openapi3.T) []openax.LintFinding {
+		var findings []openax.LintFinding
+		for _, path := range doc.Paths.InMatchingOrder() {
+			for method, operation := range doc.Paths.Value(path).Operations() {
+				if operation.OperationID != "" && !camelCase.MatchString(operation.OperationID) {
+					findings = append(findings, openax.LintFinding{
+						Rule:     "operation-id-camel-case",
+						Severity: openax.SeverityError,
+						Message:  fmt.Sprintf("operationId %q of %s %s is not camelCase", operation.OperationID, method, path),
+					})
+				}
+			}
+		}
+		return findings
+	})
+
+	t.Run("registered rule runs alongside built-ins", func(t *testing.T) {
+		t.Cleanup(openax.RegisterRule(camelCaseRule))
+
+		findings := client.Lint(doc, openax.LintOptions{})
+		require.Len(t, findings, 1)
+		assert.Equal(t, "operation-id-camel-case", findings[0].Rule)
+		assert.Contains(t, findings[0].Message, `"create_pet"`)
+	})
+
+	t.Run("unregistered rule no longer runs", func(t *testing.T) {
+		assert.Empty(t, client.Lint(doc, openax.LintOptions{}))
+	})
+
+	t.Run("example rules", func(t *testing.T) {
+		findings := openax.Lint(doc, openax.OperationIDRule, openax.SuccessExampleRule)
+		require.Len(t, findings, 2)
+
+		assert.Equal(t, openax.RuleOperationID, findings[0].Rule)
+		assert.Equal(t, "paths./health.get", findings[0].Location.Path)
+		assert.Equal(t, openax.RuleSuccessExample, findings[1].Rule)
+		assert.Equal(t, "paths./pets.post.responses.201.content.application/json", findings[1].Location.Path)
+	})
+}
+
+func TestLintRequestBodyRequired(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Lint API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+    patch:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	findings := client.Lint(doc, openax.LintOptions{CheckRequestBodyRequired: true})
+	require.Len(t, findings, 1)
+
+	assert.Equal(t, openax.RuleRequestBodyRequired, findings[0].Rule)
+	require.NotNil(t, findings[0].Location)
+	assert.Equal(t, "paths./pets.patch.requestBody", findings[0].Location.Path)
+}
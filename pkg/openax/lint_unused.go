@@ -0,0 +1,87 @@
+package openax
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// lintUnusedComponents reports schemas, parameters, request bodies, and responses
+// that are defined in components but never referenced from the document's operations
+func lintUnusedComponents(doc *openapi3.T) []LintFinding {
+	var findings []LintFinding
+	if doc.Components == nil {
+		return findings
+	}
+
+	usage, err := collectDocumentUsage(doc)
+	if err != nil {
+		return append(findings, LintFinding{
+			Rule:     RuleUnusedComponents,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("could not determine component usage: %v", err),
+		})
+	}
+
+	report := func(category string, names []string, used map[string]bool) {
+		for _, name := range names {
+			if used[name] {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Rule:     RuleUnusedComponents,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s %q is never referenced", category, name),
+				Location: createLocation(fmt.Sprintf("components.%s.%s", category, name)),
+			})
+		}
+	}
+
+	report(ComponentSchemas, sortedKeys(doc.Components.Schemas), usage.Schemas)
+	report(ComponentParameters, sortedKeys(doc.Components.Parameters), usage.Parameters)
+	report(ComponentRequestBodies, sortedKeys(doc.Components.RequestBodies), usage.RequestBodies)
+	report(ComponentResponses, sortedKeys(doc.Components.Responses), usage.Responses)
+
+	return findings
+}
+
+// collectDocumentUsage marks every component referenced, directly or transitively,
+// from any operation or path-level parameter in the document
+func collectDocumentUsage(doc *openapi3.T) (*ComponentUsage, error) {
+	usage := &ComponentUsage{
+		Schemas:       make(map[string]bool),
+		Parameters:    make(map[string]bool),
+		RequestBodies: make(map[string]bool),
+		Responses:     make(map[string]bool),
+	}
+	if doc.Components == nil || doc.Paths == nil {
+		return usage, nil
+	}
+
+	mimeTypes := findAllMimeTypes(doc)
+	for _, pathItem := range doc.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+
+		// Path-level parameters are shared by every operation under the path
+		shared := &openapi3.Operation{Parameters: pathItem.Parameters}
+		if err := processOperationParameters(doc, shared, usage.Schemas, usage.Parameters); err != nil {
+			return nil, err
+		}
+
+		for _, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			err := collectReferencesFromOperation(doc, operation, mimeTypes,
+				usage.Schemas, usage.RequestBodies, usage.Parameters, usage.Responses)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	findTransitivelyUsedComponents(doc, usage)
+	return usage, nil
+}
@@ -0,0 +1,52 @@
+package openax
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ComponentManifest maps a component category ("schemas", "parameters",
+// "requestBodies", "responses") to the sorted names of its components.
+type ComponentManifest map[string][]string
+
+// Manifest lists every component the filtered specification depends on, by
+// category and name, e.g. for build systems that cache per-component artifacts.
+// Unlike the counts in a FilterReport, the manifest names the exact components.
+//
+// Pruning is always enabled and NoPrune is ignored, so only components reachable
+// from the retained operations (or selected by Schemas or Keep) are listed.
+// Categories without components are omitted. Nil is returned if filtering fails.
+//
+// Example:
+//
+//	manifest := openax.Manifest(doc, openax.FilterOptions{Tags: []string{"pets"}})
+//	fmt.Println(manifest["schemas"]) // [Category Pet Tag]
+func Manifest(doc *openapi3.T, opts FilterOptions) ComponentManifest {
+	opts.PruneComponents = true
+	opts.NoPrune = nil
+	filtered, err := applyFilter(doc, opts)
+	if err != nil {
+		return nil
+	}
+
+	return ListComponents(filtered)
+}
+
+// ListComponents lists the components a specification defines, by category and
+// name, without filtering it. Use it on an already filtered specification to get
+// the manifest of exactly what it contains. Categories without components are
+// omitted.
+func ListComponents(doc *openapi3.T) ComponentManifest {
+	manifest := make(ComponentManifest)
+	add := func(category string, names []string) {
+		if len(names) > 0 {
+			manifest[category] = names
+		}
+	}
+	if components := doc.Components; components != nil {
+		add(ComponentSchemas, sortedKeys(components.Schemas))
+		add(ComponentParameters, sortedKeys(components.Parameters))
+		add(ComponentRequestBodies, sortedKeys(components.RequestBodies))
+		add(ComponentResponses, sortedKeys(components.Responses))
+	}
+	return manifest
+}
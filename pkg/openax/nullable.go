@@ -0,0 +1,178 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SpecVersion names an OpenAPI minor version whose idioms a transform targets.
+type SpecVersion string
+
+// Supported spec versions.
+const (
+	SpecVersion30 SpecVersion = "3.0"
+	SpecVersion31 SpecVersion = "3.1"
+)
+
+// NormalizeNullable returns a copy of the specification in which every schema
+// expresses nullability the way the target version does: `nullable: true` for
+// 3.0, and a "null" entry in the type array for 3.1. This keeps documents merged
+// from 3.0 and 3.1 sources consistent. The openapi version field is left alone.
+//
+// A 3.1 schema whose only type is "null" becomes a 3.0 schema with no type that
+// is nullable. A nullable 3.0 schema without a type, such as the common
+// `nullable: true` with `allOf: [$ref]`, becomes `anyOf: [<schema>, {type: null}]`
+// in 3.1, since the schemas it composes may not accept null themselves. An enum
+// gains a null entry, and a const becomes an enum of its value and null.
+//
+// The source document is not modified, and the result shares no objects with it.
+//
+// Example:
+//
+//	normalized, err := openax.NormalizeNullable(merged, openax.SpecVersion31)
+func NormalizeNullable(doc *openapi3.T, target SpecVersion) (*openapi3.T, error) {
+	var normalize func(schema *openapi3.Schema)
+	switch target {
+	case SpecVersion30:
+		normalize = nullableTo30
+	case SpecVersion31:
+		normalize = nullableTo31
+	default:
+		return nil, fmt.Errorf("unsupported spec version: %q (supported: %s, %s)", target, SpecVersion30, SpecVersion31)
+	}
+
+	result := deepCopySpec(doc)
+	walkDocumentSchemas(result, normalize)
+	return result, nil
+}
+
+// nullableTo30 replaces a "null" type entry with the nullable keyword
+func nullableTo30(schema *openapi3.Schema) {
+	if schema.Type == nil || !schema.Type.Includes(openapi3.TypeNull) {
+		return
+	}
+	types := slices.DeleteFunc(slices.Clone(schema.Type.Slice()), func(typ string) bool {
+		return typ == openapi3.TypeNull
+	})
+	if len(types) == 0 {
+		schema.Type = nil
+	} else {
+		schema.Type = (*openapi3.Types)(&types)
+	}
+	schema.Nullable = true
+}
+
+// nullableTo31 replaces the nullable keyword with a "null" type entry, or with an
+// anyOf offering null when the schema has no type to extend
+func nullableTo31(schema *openapi3.Schema) {
+	if !schema.Nullable {
+		return
+	}
+	schema.Nullable = false
+	if schema.Type == nil || len(schema.Type.Slice()) == 0 {
+		original := *schema
+		*schema = openapi3.Schema{AnyOf: openapi3.SchemaRefs{
+			{Value: &original},
+			{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNull}}},
+		}}
+		return
+	}
+	allowNullValue(schema)
+	if schema.Type.Includes(openapi3.TypeNull) {
+		return
+	}
+	types := append(slices.Clone(schema.Type.Slice()), openapi3.TypeNull)
+	schema.Type = (*openapi3.Types)(&types)
+}
+
+// allowNullValue adds null to the values an enum or const permits, which in 3.1
+// would otherwise reject null whatever the type says
+func allowNullValue(schema *openapi3.Schema) {
+	if value, ok := schema.Extensions["const"]; ok && value != nil {
+		delete(schema.Extensions, "const")
+		schema.Enum = []any{value}
+	}
+	isNull := func(value any) bool { return value == nil }
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, isNull) {
+		schema.Enum = append(slices.Clone(schema.Enum), nil)
+	}
+}
+
+// walkDocumentSchemas calls visit for every schema of the document: those defined
+// inline in operations and components, and the component schemas. Schemas are
+// walked with walkInlineSchemas, so each is visited where it is defined.
+func walkDocumentSchemas(doc *openapi3.T, visit func(schema *openapi3.Schema)) {
+	walk := func(schema *openapi3.SchemaRef) {
+		walkInlineSchemas(schema, "", func(schema *openapi3.Schema, _ string) {
+			visit(schema)
+		})
+	}
+	content := func(content openapi3.Content) {
+		for _, mediaType := range content {
+			if mediaType != nil {
+				walk(mediaType.Schema)
+			}
+		}
+	}
+	parameters := func(params openapi3.Parameters) {
+		for _, param := range params {
+			if param != nil && param.Ref == "" && param.Value != nil {
+				walk(param.Value.Schema)
+				content(param.Value.Content)
+			}
+		}
+	}
+	headers := func(headers openapi3.Headers) {
+		for _, header := range headers {
+			if header != nil && header.Ref == "" && header.Value != nil {
+				walk(header.Value.Schema)
+				content(header.Value.Content)
+			}
+		}
+	}
+	responses := func(response *openapi3.ResponseRef) {
+		if response != nil && response.Ref == "" && response.Value != nil {
+			content(response.Value.Content)
+			headers(response.Value.Headers)
+		}
+	}
+
+	for _, pathItem := range doc.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		parameters(pathItem.Parameters)
+		for _, operation := range pathItem.Operations() {
+			parameters(operation.Parameters)
+			if requestBody := operation.RequestBody; requestBody != nil && requestBody.Ref == "" && requestBody.Value != nil {
+				content(requestBody.Value.Content)
+			}
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.Map() {
+					responses(response)
+				}
+			}
+		}
+	}
+
+	if doc.Components == nil {
+		return
+	}
+	for _, schema := range doc.Components.Schemas {
+		walk(schema)
+	}
+	for _, param := range doc.Components.Parameters {
+		parameters(openapi3.Parameters{param})
+	}
+	headers(doc.Components.Headers)
+	for _, requestBody := range doc.Components.RequestBodies {
+		if requestBody != nil && requestBody.Value != nil {
+			content(requestBody.Value.Content)
+		}
+	}
+	for _, response := range doc.Components.Responses {
+		responses(response)
+	}
+}
@@ -0,0 +1,143 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeNullable(t *testing.T) {
+	t.Run("3.0 to 3.1", func(t *testing.T) {
+		doc, err := openax.New().LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Nullable
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+      nullable: true
+`))
+		require.NoError(t, err)
+
+		normalized, err := openax.NormalizeNullable(doc, openax.SpecVersion31)
+		require.NoError(t, err)
+
+		name := normalized.Components.Schemas["Name"].Value
+		assert.False(t, name.Nullable)
+		assert.Equal(t, &openapi3.Types{"string", "null"}, name.Type)
+
+		// The source document is not modified
+		assert.True(t, doc.Components.Schemas["Name"].Value.Nullable)
+	})
+
+	t.Run("3.1 to 3.0", func(t *testing.T) {
+		doc, err := openax.New().LoadFromData([]byte(`openapi: 3.1.0
+info:
+  title: Nullable
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: [string, "null"]
+`))
+		require.NoError(t, err)
+
+		normalized, err := openax.NormalizeNullable(doc, openax.SpecVersion30)
+		require.NoError(t, err)
+
+		name := normalized.Components.Schemas["Name"].Value
+		assert.True(t, name.Nullable)
+		assert.Equal(t, &openapi3.Types{"string"}, name.Type)
+	})
+
+	t.Run("3.0 composed schema to 3.1", func(t *testing.T) {
+		doc, err := openax.New().LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Nullable
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Owner:
+      type: object
+    Pet:
+      type: object
+      properties:
+        owner:
+          nullable: true
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+`))
+		require.NoError(t, err)
+
+		normalized, err := openax.NormalizeNullable(doc, openax.SpecVersion31)
+		require.NoError(t, err)
+
+		owner := normalized.Components.Schemas["Pet"].Value.Properties["owner"].Value
+		assert.False(t, owner.Nullable)
+		assert.Nil(t, owner.Type)
+		assert.Empty(t, owner.AllOf)
+		require.Len(t, owner.AnyOf, 2, "Null should be offered alongside the composed schema")
+
+		original := owner.AnyOf[0].Value
+		assert.False(t, original.Nullable)
+		require.Len(t, original.AllOf, 1)
+		assert.Equal(t, "#/components/schemas/Owner", original.AllOf[0].Ref)
+		assert.Equal(t, &openapi3.Types{"null"}, owner.AnyOf[1].Value.Type)
+
+		// The referenced schema itself still rejects null
+		assert.Equal(t, &openapi3.Types{"object"}, normalized.Components.Schemas["Owner"].Value.Type)
+	})
+
+	t.Run("3.0 enum and const to 3.1", func(t *testing.T) {
+		doc, err := openax.New().LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Nullable
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [active, inactive]
+      nullable: true
+    Kind:
+      type: string
+      const: pet
+      nullable: true
+    Listed:
+      type: string
+      enum: [a, null]
+      nullable: true
+`))
+		require.NoError(t, err)
+
+		normalized, err := openax.NormalizeNullable(doc, openax.SpecVersion31)
+		require.NoError(t, err)
+
+		status := normalized.Components.Schemas["Status"].Value
+		assert.Equal(t, &openapi3.Types{"string", "null"}, status.Type)
+		assert.Equal(t, []any{"active", "inactive", nil}, status.Enum)
+
+		kind := normalized.Components.Schemas["Kind"].Value
+		assert.NotContains(t, kind.Extensions, "const")
+		assert.Equal(t, []any{"pet", nil}, kind.Enum)
+
+		assert.Equal(t, []any{"a", nil}, normalized.Components.Schemas["Listed"].Value.Enum, "null should not be listed twice")
+
+		// The source document is not modified
+		assert.Equal(t, []any{"active", "inactive"}, doc.Components.Schemas["Status"].Value.Enum)
+		assert.Contains(t, doc.Components.Schemas["Kind"].Value.Extensions, "const")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := openax.NormalizeNullable(&openapi3.T{}, "2.0")
+		assert.Error(t, err)
+	})
+}
@@ -56,8 +56,11 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/imtanmoy/openax/pkg/loader"
 )
 
 // FilterOptions defines the filtering criteria for OpenAPI specifications.
@@ -87,16 +90,151 @@ type FilterOptions struct {
 
 	// Tags specifies which OpenAPI tags to include.
 	// Only operations with at least one of these tags will be included.
+	// For slash-delimited tag hierarchies, "admin/*" selects the direct children
+	// of "admin" (e.g., "admin/users") and "admin/**" selects all its descendants.
 	// If empty, all tags are included.
 	Tags []string
 
+	// TagDescriptionMatch is a regular expression matched against the descriptions
+	// of the top-level tags. Only operations with at least one tag whose description
+	// matches will be included. Combined with Tags using AND logic.
+	// If empty, no tag description filtering is applied.
+	TagDescriptionMatch string
+
+	// Schemas specifies component schema names to select operations by.
+	// Only operations whose request body, parameters, or responses reference one of
+	// these schemas (directly or transitively) will be included, along with the
+	// named schemas themselves. Unknown schema names cause an error.
+	// If empty, no schema filtering is applied.
+	Schemas []string
+
+	// HasParameters specifies parameter names to select operations by.
+	// Only operations declaring a parameter with one of these names, inline, via
+	// $ref, or at the path level, will be included.
+	// If empty, no parameter filtering is applied.
+	HasParameters []string
+
+	// RequireRequestBody retains only operations whose request body is marked
+	// required, e.g. for a minimal write surface. Operations without a request body
+	// or with an optional one are excluded.
+	RequireRequestBody bool
+
+	// OperationSelectors is an allowlist of exact method and path pairs, e.g.
+	// {Method: "GET", Path: "/pets"}. Selected operations are retained regardless
+	// of the other selection filters, without the method/operationId ambiguity of
+	// Operations. Selectors that match no operation cause an error.
+	OperationSelectors []OperationSelector
+
+	// Keep force-includes components by name regardless of whether any retained
+	// operation references them, keyed by component category ("schemas",
+	// "parameters", "requestBodies", "responses"). Each kept component is pulled in
+	// along with the schemas it references and survives pruning.
+	// Unknown categories or component names cause an error.
+	Keep map[string][]string
+
+	// MaxPathsPerTag caps how many matched paths each tag contributes, for balanced
+	// samples of large specs. Paths are chosen deterministically in lexical order; a
+	// path counts towards every tag of its operations and is skipped once any of them
+	// is full. Untagged paths share one bucket. Paths added by IncludeParentPaths or
+	// FollowLinks must also be part of the sample. Zero means no cap.
+	MaxPathsPerTag int
+
+	// KeepEmptyPaths keeps retained paths whose path item has no operations, such as
+	// paths included by the Paths filter that only declare parameters. By default
+	// they are dropped.
+	KeepEmptyPaths bool
+
+	// KeepEmptyContent keeps responses that content-type folding left without
+	// content quietly. By default each one is reported as a warning. Filtering
+	// never removes individual media types or request bodies, so empty media
+	// types in the source are always kept.
+	KeepEmptyContent bool
+
 	// PruneComponents removes unused components (schemas, parameters, etc.)
 	// from the filtered specification to reduce size.
 	// This is useful when creating minimal API specifications.
 	// This helps reduce specification size and improves readability
 	PruneComponents bool
+
+	// PruneSecuritySchemes also removes, when PruneComponents is enabled, the
+	// security schemes no retained operation or top-level security requirement
+	// uses, and the unused scopes of retained OAuth2 schemes. Security schemes are
+	// kept by default since tooling may rely on schemes no operation requires.
+	PruneSecuritySchemes bool
+
+	// NoPrune lists component categories (e.g., ComponentSchemas) that are kept
+	// intact when PruneComponents is enabled. Unknown categories cause an error.
+	NoPrune []string
+
+	// StripExtensions lists vendor extensions (e.g., "x-internal-notes") removed from
+	// the top-level document, operations, parameters, responses, and schemas.
+	// A trailing "*" matches by prefix, so "x-internal*" strips all internal extensions.
+	StripExtensions []string
+
+	// OperationIDCase rewrites retained operationIds to the chosen naming convention
+	// and updates links.operationId references to match. The default preserves them.
+	// Distinct operationIds that normalize to the same value cause an error.
+	OperationIDCase OperationIDCase
+
+	// EmbedProvenance writes an x-openax extension on the filtered document recording
+	// the applied filters, the tool version, and the generation timestamp.
+	EmbedProvenance bool
+
+	// FollowLinks also includes operations targeted by the response links
+	// (links.operationId) of matched operations, transitively up to a fixed depth.
+	// This keeps the filtered specification self-contained when links are present.
+	// Without it, links whose target operation is filtered out are removed and
+	// reported as warnings.
+	FollowLinks bool
+
+	// StripUnusedTags controls whether top-level tags not used by any retained
+	// operation are removed, independently of PruneComponents. Nil (the default) and
+	// true strip unused tags; false keeps every top-level tag of the source.
+	StripUnusedTags *bool
+
+	// ResolveServerVariables substitutes each server variable's default into the
+	// server URL and removes the variables map, for clients that cannot expand
+	// templated URLs. A variable without a default causes an error.
+	ResolveServerVariables bool
+
+	// IncludeParentPaths also retains the collection endpoint of every retained item
+	// endpoint, i.e. the path one segment up from a path ending in a path parameter
+	// (/pets for /pets/{id}), when it exists in the source. This keeps generated
+	// clients coherent.
+	IncludeParentPaths bool
+
+	// CanonicalResponseContentType reduces every retained response to this single
+	// content type (e.g. "application/json"). A response lacking it reuses the schema
+	// of its other content types when they are all equivalent; otherwise its content
+	// is dropped.
+	CanonicalResponseContentType string
+
+	// VersionSuffix is appended to info.version of the filtered specification to
+	// tell the subset apart from the full API, e.g. "+public" for "1.0.0+public".
+	VersionSuffix string
+
+	// DeepCopy makes the filtered specification fully independent of the source.
+	// By default retained objects (operations, schemas, ...) are shared with the
+	// source document, so modifying them in the result also modifies the source.
+	// Copying walks the whole filtered specification once.
+	DeepCopy bool
 }
 
+// OperationSelector identifies a single operation for FilterOptions.OperationSelectors.
+type OperationSelector struct {
+	Method string `json:"method"` // HTTP method, matched case-insensitively
+	Path   string `json:"path"`   // Path template, matched exactly
+}
+
+// Component categories accepted by FilterOptions.NoPrune.
+const (
+	ComponentSchemas         = "schemas"
+	ComponentParameters      = "parameters"
+	ComponentRequestBodies   = "requestBodies"
+	ComponentResponses       = "responses"
+	ComponentSecuritySchemes = "securitySchemes"
+)
+
 // LoadOptions defines configuration options for creating OpenAx clients.
 //
 // These options control how OpenAPI specifications are loaded and processed.
@@ -106,9 +244,22 @@ type LoadOptions struct {
 	// Default: false for security reasons.
 	AllowExternalRefs bool
 
-	// Context provides cancellation and deadline control for loading operations.
+	// Context provides cancellation and deadline control for loading operations,
+	// including remote fetches, and for validation.
 	// If nil, context.Background() is used.
 	Context context.Context
+
+	// Retries is the number of additional attempts made when fetching a remote
+	// document, including external $ref targets, fails with a network error or a
+	// 5xx/429 response. Zero disables retries.
+	Retries int
+
+	// RetryBackoff is the delay before the first retry; it doubles after every
+	// failed attempt. Defaults to 100ms when retries are enabled.
+	RetryBackoff time.Duration
+
+	// Timeout bounds each remote fetch. Zero means no timeout.
+	Timeout time.Duration
 }
 
 // Client provides the main OpenAx functionality for loading, filtering, and validating
@@ -123,7 +274,8 @@ type LoadOptions struct {
 //	doc, err := client.LoadFromFile("api.yaml")
 //	filtered, err := client.Filter(doc, options)
 type Client struct {
-	loader *openapi3.Loader
+	opts   LoadOptions
+	loader atomic.Pointer[openapi3.Loader]
 }
 
 // New creates a new OpenAx client with default options.
@@ -156,19 +308,45 @@ func New() *Client {
 //		Context:           ctx,
 //	})
 func NewWithOptions(opts LoadOptions) *Client {
-	ctx := opts.Context
-	if ctx == nil {
-		ctx = context.Background()
+	if opts.Context == nil {
+		opts.Context = context.Background()
 	}
 
-	return &Client{
-		loader: &openapi3.Loader{
-			Context:               ctx,
-			IsExternalRefsAllowed: opts.AllowExternalRefs,
-		},
+	client := &Client{opts: opts}
+	client.loader.Store(newLoader(opts))
+	return client
+}
+
+// newLoader creates a loader with an empty document cache
+func newLoader(opts LoadOptions) *openapi3.Loader {
+	return &openapi3.Loader{
+		Context:               opts.Context,
+		IsExternalRefsAllowed: opts.AllowExternalRefs,
+		ReadFromURIFunc: loader.NewReadFromURIFunc(loader.Options{
+			Retries:      opts.Retries,
+			RetryBackoff: opts.RetryBackoff,
+			Timeout:      opts.Timeout,
+		}),
 	}
 }
 
+// Close releases the resources the client holds, such as the documents and remote
+// references it has read and cached. Calling Close is optional; a client that is
+// simply dropped is garbage collected like any other value.
+//
+// The client remains usable after Close: later loads start from an empty cache,
+// so files and URLs are read again. Close is safe to call more than once and
+// concurrently with other methods, and currently always returns nil.
+//
+// Example:
+//
+//	client := openax.New()
+//	defer client.Close()
+func (c *Client) Close() error {
+	c.loader.Store(newLoader(c.opts))
+	return nil
+}
+
 // LoadFromFile loads an OpenAPI specification from a local file.
 //
 // The file can be in YAML or JSON format. The file path should be absolute
@@ -181,7 +359,7 @@ func NewWithOptions(opts LoadOptions) *Client {
 //		log.Fatal(err)
 //	}
 func (c *Client) LoadFromFile(filePath string) (*openapi3.T, error) {
-	return c.loader.LoadFromFile(filePath)
+	return c.loader.Load().LoadFromFile(filePath)
 }
 
 // LoadFromURL loads an OpenAPI specification from a remote URL.
@@ -200,7 +378,7 @@ func (c *Client) LoadFromURL(urlStr string) (*openapi3.T, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
-	return c.loader.LoadFromURI(u)
+	return c.loader.Load().LoadFromURI(u)
 }
 
 // LoadFromData loads an OpenAPI specification from raw byte data.
@@ -216,7 +394,25 @@ func (c *Client) LoadFromURL(urlStr string) (*openapi3.T, error) {
 //		log.Fatal(err)
 //	}
 func (c *Client) LoadFromData(data []byte) (*openapi3.T, error) {
-	return c.loader.LoadFromData(data)
+	return c.loader.Load().LoadFromData(data)
+}
+
+// LoadFromSource loads an OpenAPI specification from a file path or URL.
+//
+// Sources starting with http:// or https:// are loaded from the network,
+// otherwise they are treated as file paths.
+//
+// Example:
+//
+//	doc, err := client.LoadFromSource("https://api.example.com/openapi.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Client) LoadFromSource(source string) (*openapi3.T, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return c.LoadFromURL(source)
+	}
+	return c.LoadFromFile(source)
 }
 
 // Validate validates an OpenAPI specification against the OpenAPI 3.x standard.
@@ -232,14 +428,15 @@ func (c *Client) LoadFromData(data []byte) (*openapi3.T, error) {
 //		log.Printf("Validation failed: %v", err)
 //	}
 func (c *Client) Validate(doc *openapi3.T) error {
-	return doc.Validate(c.loader.Context)
+	return doc.Validate(c.opts.Context)
 }
 
 // Filter applies filtering to an OpenAPI specification based on the provided options.
 //
 // It returns a new specification containing only the requested paths, operations, and tags,
 // along with all referenced components (schemas, parameters, request bodies, responses).
-// The original specification is not modified.
+// The original specification is not modified. Retained objects are shared with it
+// unless FilterOptions.DeepCopy is set.
 //
 // Component dependency resolution is handled automatically - all components referenced
 // by filtered operations are included in the result, ensuring the filtered specification
@@ -257,6 +454,30 @@ func (c *Client) Filter(doc *openapi3.T, opts FilterOptions) (*openapi3.T, error
 	return applyFilter(doc, opts)
 }
 
+// FilterWithReport applies filtering like Filter and also returns a report of
+// non-fatal issues found along the way.
+//
+// Warnings do not fail filtering; they let callers such as CI pipelines surface
+// oddities like operations using tags that are not declared at the top level.
+//
+// Example:
+//
+//	filtered, report, err := client.FilterWithReport(doc, opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, warning := range report.Warnings {
+//		log.Printf("warning: %s", warning)
+//	}
+func (c *Client) FilterWithReport(doc *openapi3.T, opts FilterOptions) (*openapi3.T, *FilterReport, error) {
+	report := &FilterReport{}
+	filtered, err := applyFilterWithReport(doc, opts, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filtered, report, nil
+}
+
 // LoadAndFilter is a convenience method that loads and filters a specification in one call.
 //
 // This combines loading (from file or URL) and filtering into a single operation.
@@ -275,15 +496,31 @@ func (c *Client) Filter(doc *openapi3.T, opts FilterOptions) (*openapi3.T, error
 //	// Load and filter from URL
 //	filtered, err := client.LoadAndFilter("https://api.example.com/spec.yaml", opts)
 func (c *Client) LoadAndFilter(source string, opts FilterOptions) (*openapi3.T, error) {
-	var doc *openapi3.T
-	var err error
+	doc, err := c.LoadFromSource(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec: %w", err)
+	}
 
-	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
-		doc, err = c.LoadFromURL(source)
-	} else {
-		doc, err = c.LoadFromFile(source)
+	if err := c.Validate(doc); err != nil {
+		return nil, fmt.Errorf("spec validation failed: %w", err)
 	}
 
+	return c.Filter(doc, opts)
+}
+
+// FilterBytes is a convenience method that loads, validates, filters, and encodes
+// a specification held in memory.
+//
+// The data may be YAML or JSON. The result is serialized in the requested format
+// using Encode, so unknown formats return an UnsupportedFormatError.
+//
+// Example:
+//
+//	out, err := client.FilterBytes(specData, openax.FilterOptions{
+//		Tags: []string{"users"},
+//	}, "json")
+func (c *Client) FilterBytes(data []byte, opts FilterOptions, format string) ([]byte, error) {
+	doc, err := c.LoadFromData(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load spec: %w", err)
 	}
@@ -292,7 +529,12 @@ func (c *Client) LoadAndFilter(source string, opts FilterOptions) (*openapi3.T,
 		return nil, fmt.Errorf("spec validation failed: %w", err)
 	}
 
-	return c.Filter(doc, opts)
+	filtered, err := c.Filter(doc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return Encode(filtered, format)
 }
 
 // ValidateOnly loads and validates a specification without filtering.
@@ -310,15 +552,7 @@ func (c *Client) LoadAndFilter(source string, opts FilterOptions) (*openapi3.T,
 //	// Validate a remote spec
 //	err := client.ValidateOnly("https://api.example.com/openapi.yaml")
 func (c *Client) ValidateOnly(source string) error {
-	var doc *openapi3.T
-	var err error
-
-	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
-		doc, err = c.LoadFromURL(source)
-	} else {
-		doc, err = c.LoadFromFile(source)
-	}
-
+	doc, err := c.LoadFromSource(source)
 	if err != nil {
 		return fmt.Errorf("failed to load spec: %w", err)
 	}
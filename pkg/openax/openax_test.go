@@ -2,8 +2,16 @@ package openax_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/imtanmoy/openax/pkg/openax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +31,34 @@ func TestNewWithOptions(t *testing.T) {
 	require.NotNil(t, client, "NewWithOptions() should not return nil")
 }
 
+func TestClose(t *testing.T) {
+	client := openax.New()
+
+	specPath := filepath.Join(t.TempDir(), "api.yaml")
+	writeSpec := func(title string) {
+		spec := fmt.Sprintf("openapi: 3.0.3\ninfo:\n  title: %s\n  version: 1.0.0\npaths: {}\n", title)
+		require.NoError(t, os.WriteFile(specPath, []byte(spec), 0600))
+	}
+
+	writeSpec("First")
+	doc, err := client.LoadFromFile(specPath)
+	require.NoError(t, err)
+	assert.Equal(t, "First", doc.Info.Title)
+
+	// The cached document is served until the client is closed
+	writeSpec("Second")
+	doc, err = client.LoadFromFile(specPath)
+	require.NoError(t, err)
+	assert.Equal(t, "First", doc.Info.Title)
+
+	require.NoError(t, client.Close())
+	doc, err = client.LoadFromFile(specPath)
+	require.NoError(t, err, "A closed client should remain usable")
+	assert.Equal(t, "Second", doc.Info.Title)
+
+	assert.NoError(t, client.Close(), "Close should be safe to call more than once")
+}
+
 func TestLoadFromFile(t *testing.T) {
 	client := openax.New()
 
@@ -203,6 +239,1276 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterPreservesJSONSchemaDialect(t *testing.T) {
+	client := openax.New()
+
+	spec := []byte(`openapi: 3.1.0
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+info:
+  title: Dialect API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      tags: [users]
+      responses:
+        '200':
+          description: OK
+`)
+
+	doc, err := client.LoadFromData(spec)
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"users"}})
+	require.NoError(t, err, "Filter should not fail")
+
+	data, err := json.Marshal(filtered)
+	require.NoError(t, err, "Failed to marshal filtered spec")
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "https://spec.openapis.org/oas/3.1/dialect/base", out["jsonSchemaDialect"],
+		"jsonSchemaDialect should survive filtering")
+}
+
+func TestFilterBySchemas(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("operations touching Pet", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Schemas:         []string{"Pet"},
+			PruneComponents: true,
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		operationIDs := make(map[string]bool)
+		for _, pathItem := range filtered.Paths.Map() {
+			for _, operation := range pathItem.Operations() {
+				operationIDs[operation.OperationID] = true
+			}
+		}
+
+		for _, expected := range []string{"addPet", "updatePet", "findPetsByStatus", "findPetsByTags", "getPetById"} {
+			assert.True(t, operationIDs[expected], "Expected operation %s to be retained", expected)
+		}
+		assert.False(t, operationIDs["deletePet"], "deletePet does not use Pet")
+		assert.False(t, operationIDs["placeOrder"], "placeOrder does not use Pet")
+		assert.False(t, operationIDs["createUser"], "createUser does not use Pet")
+
+		assert.Contains(t, filtered.Components.Schemas, "Pet")
+		assert.Contains(t, filtered.Components.Schemas, "Category")
+		assert.NotContains(t, filtered.Components.Schemas, "Order")
+		assert.NotContains(t, filtered.Components.Schemas, "User")
+	})
+
+	t.Run("unknown schema", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{Schemas: []string{"Unicorn"}})
+
+		var notFound *openax.ComponentNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestFilterKeep(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Shared Models
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        category:
+          $ref: '#/components/schemas/Category'
+    Category:
+      type: object
+    Error:
+      type: object
+    Unused:
+      type: object
+    Blob:
+      type: string
+  requestBodies:
+    Upload:
+      content:
+        application/cbor:
+          schema:
+            $ref: '#/components/schemas/Blob'
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+    Conflict:
+      description: Conflict
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("kept components and their closure survive pruning", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Keep: map[string][]string{
+				openax.ComponentSchemas:   {"Pet"},
+				openax.ComponentResponses: {"NotFound"},
+			},
+			PruneComponents: true,
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Empty(t, filtered.Paths.Map())
+		assert.ElementsMatch(t, []string{"Pet", "Category", "Error"}, slices.Collect(maps.Keys(filtered.Components.Schemas)))
+		assert.ElementsMatch(t, []string{"NotFound"}, slices.Collect(maps.Keys(filtered.Components.Responses)))
+	})
+
+	t.Run("kept content is walked for every media type", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Keep:            map[string][]string{openax.ComponentRequestBodies: {"Upload"}},
+			PruneComponents: true,
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.ElementsMatch(t, []string{"Blob"}, slices.Collect(maps.Keys(filtered.Components.Schemas)),
+			"Schemas of media types no operation uses should be kept")
+	})
+
+	t.Run("unknown component name", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{
+			Keep: map[string][]string{openax.ComponentResponses: {"Teapot"}},
+		})
+
+		var notFound *openax.ComponentNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{
+			Keep: map[string][]string{"widgets": {"Pet"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterIncludeParentPaths(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("parent path retained", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Paths:              []string{"/pet/{petId}"},
+			IncludeParentPaths: true,
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.NotNil(t, filtered.Paths.Value("/pet/{petId}"))
+		assert.NotNil(t, filtered.Paths.Value("/pet"), "Collection endpoint should be included")
+		assert.Nil(t, filtered.Paths.Value("/pet/findByStatus"), "Sibling paths should not be included")
+		assert.Contains(t, filtered.Components.Schemas, "Pet")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{Paths: []string{"/pet/{petId}"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Nil(t, filtered.Paths.Value("/pet"))
+	})
+}
+
+func TestFilterByTagDescription(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Audience API
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Pet operations (audience public)
+  - name: stores
+    description: Store operations (audience public)
+  - name: admin
+    description: Internal administration
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      responses:
+        '200':
+          description: OK
+  /stores:
+    get:
+      tags: [stores]
+      responses:
+        '200':
+          description: OK
+  /admin:
+    get:
+      tags: [admin]
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("operations tagged with matching descriptions", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{TagDescriptionMatch: "public"})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.NotNil(t, filtered.Paths.Value("/pets"))
+		assert.NotNil(t, filtered.Paths.Value("/stores"))
+		assert.Nil(t, filtered.Paths.Value("/admin"))
+	})
+
+	t.Run("combined with tags", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Tags:                []string{"pets", "admin"},
+			TagDescriptionMatch: "public",
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Equal(t, 1, filtered.Paths.Len())
+		assert.NotNil(t, filtered.Paths.Value("/pets"))
+	})
+
+	t.Run("no matching descriptions", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{TagDescriptionMatch: "partner"})
+		require.NoError(t, err, "Filter should not fail")
+		assert.Equal(t, 0, filtered.Paths.Len())
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{TagDescriptionMatch: "("})
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterHierarchicalTags(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Admin
+  version: 1.0.0
+paths:
+  /admin:
+    get:
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+  /admin/users:
+    get:
+      tags: [admin/users]
+      responses:
+        "200":
+          description: OK
+  /admin/roles:
+    get:
+      tags: [admin/roles]
+      responses:
+        "200":
+          description: OK
+  /admin/roles/{roleId}/grants:
+    get:
+      tags: [admin/roles/grants]
+      responses:
+        "200":
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"admin/*"}})
+	require.NoError(t, err, "Filter should not fail")
+	assert.ElementsMatch(t, []string{"/admin/users", "/admin/roles"}, filtered.Paths.InMatchingOrder(),
+		"admin/* should select the direct children of admin only")
+
+	t.Run("all descendants", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"admin/**"}})
+		require.NoError(t, err, "Filter should not fail")
+		assert.ElementsMatch(t, []string{"/admin/users", "/admin/roles", "/admin/roles/{roleId}/grants"}, filtered.Paths.InMatchingOrder())
+	})
+}
+
+func TestFilterGRPCStyleOperationIDs(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: gRPC Gateway API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: PetService/GetPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+  /pets:
+    get:
+      operationId: PetService/ListPets
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("exact match", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{Operations: []string{"PetService/GetPet"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		require.Equal(t, 1, filtered.Paths.Len())
+		require.NotNil(t, filtered.Paths.Value("/pets/{id}"))
+		assert.Equal(t, "PetService/GetPet", filtered.Paths.Value("/pets/{id}").Get.OperationID,
+			"operationId should be preserved when normalization is off")
+	})
+
+	t.Run("no partial or case-insensitive match", func(t *testing.T) {
+		for _, id := range []string{"petservice/getpet", "PetService", "GetPet"} {
+			filtered, err := client.Filter(doc, openax.FilterOptions{Operations: []string{id}})
+			require.NoError(t, err, "Filter should not fail")
+			assert.Equal(t, 0, filtered.Paths.Len(), "%q should not match", id)
+		}
+	})
+}
+
+func TestFilterMaxPathsPerTag(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{MaxPathsPerTag: 2, PruneComponents: true})
+	require.NoError(t, err, "Filter should not fail")
+
+	pathsPerTag := make(map[string]map[string]bool)
+	for path, pathItem := range filtered.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			for _, tag := range operation.Tags {
+				if pathsPerTag[tag] == nil {
+					pathsPerTag[tag] = make(map[string]bool)
+				}
+				pathsPerTag[tag][path] = true
+			}
+		}
+	}
+
+	require.NotEmpty(t, pathsPerTag)
+	for _, tag := range []string{"pet", "store", "user"} {
+		assert.Len(t, pathsPerTag[tag], 2, "Tag %s should contribute exactly the cap", tag)
+	}
+
+	// Selection is deterministic: lexically first paths win
+	assert.NotNil(t, filtered.Paths.Value("/pet"))
+	assert.NotNil(t, filtered.Paths.Value("/pet/findByStatus"))
+	assert.Nil(t, filtered.Paths.Value("/pet/{petId}"))
+
+	t.Run("negative cap", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{MaxPathsPerTag: -1})
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterStripUnusedTags(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	tagNames := func(tags openapi3.Tags) []string {
+		var names []string
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+	keep, strip := false, true
+
+	for _, prune := range []bool{false, true} {
+		t.Run(fmt.Sprintf("keep all tags with pruning %v", prune), func(t *testing.T) {
+			filtered, err := client.Filter(doc, openax.FilterOptions{
+				Tags:            []string{"store"},
+				PruneComponents: prune,
+				StripUnusedTags: &keep,
+			})
+			require.NoError(t, err, "Filter should not fail")
+			assert.Equal(t, tagNames(doc.Tags), tagNames(filtered.Tags))
+		})
+
+		t.Run(fmt.Sprintf("strip unused tags with pruning %v", prune), func(t *testing.T) {
+			filtered, err := client.Filter(doc, openax.FilterOptions{
+				Tags:            []string{"store"},
+				PruneComponents: prune,
+				StripUnusedTags: &strip,
+			})
+			require.NoError(t, err, "Filter should not fail")
+			assert.Equal(t, []string{"store"}, tagNames(filtered.Tags))
+		})
+	}
+
+	t.Run("keep all tags when no tag is used", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			Paths:           []string{"/nonexistent"},
+			StripUnusedTags: &keep,
+		})
+		require.NoError(t, err, "Filter should not fail")
+		assert.Equal(t, tagNames(doc.Tags), tagNames(filtered.Tags))
+	})
+}
+
+func TestFilterCanonicalResponseContentType(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Content Types
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            text/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{CanonicalResponseContentType: "application/json"})
+	require.NoError(t, err, "Filter should not fail")
+
+	content := filtered.Paths.Value("/pets").Get.Responses.Value("200").Value.Content
+	assert.Equal(t, []string{"application/json"}, slices.Collect(maps.Keys(content)))
+
+	// Equivalent schemas are copied under the canonical type
+	content = filtered.Paths.Value("/owners").Get.Responses.Value("200").Value.Content
+	require.Equal(t, []string{"application/json"}, slices.Collect(maps.Keys(content)))
+	assert.Equal(t, "#/components/schemas/Pet", content["application/json"].Schema.Ref)
+
+	// The source document is untouched
+	assert.Len(t, doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Content, 2)
+	assert.Len(t, doc.Paths.Value("/owners").Get.Responses.Value("200").Value.Content, 2)
+}
+
+func TestFilterEmptyContent(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Empty Content
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+            application/xml:
+              schema:
+                type: string
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+          application/xml: {}
+      responses:
+        "201":
+          description: Created
+  /uploads:
+    post:
+      requestBody:
+        content:
+          application/octet-stream: {}
+      responses:
+        "204":
+          description: No Content
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, report, err := client.FilterWithReport(doc, openax.FilterOptions{CanonicalResponseContentType: "text/plain"})
+	require.NoError(t, err, "Filter should not fail")
+
+	// Folding drops response content whose schemas differ, which is reported
+	assert.Empty(t, filtered.Paths.Value("/pets").Get.Responses.Value("200").Value.Content)
+	require.Len(t, report.Warnings, 1)
+	assert.Equal(t, "response has no content left after filtering", report.Warnings[0].Message)
+	assert.Equal(t, "paths./pets.get.responses.200", report.Warnings[0].Location.Path)
+
+	// Media types that are empty in the source are kept
+	assert.Len(t, filtered.Paths.Value("/pets").Post.RequestBody.Value.Content, 2)
+	require.NotNil(t, filtered.Paths.Value("/uploads").Post.RequestBody)
+	assert.Contains(t, filtered.Paths.Value("/uploads").Post.RequestBody.Value.Content, "application/octet-stream")
+
+	_, report, err = client.FilterWithReport(doc, openax.FilterOptions{
+		CanonicalResponseContentType: "text/plain",
+		KeepEmptyContent:             true,
+	})
+	require.NoError(t, err, "Filter should not fail")
+	assert.Empty(t, report.Warnings, "KeepEmptyContent should silence the warning")
+}
+
+func TestFilterPathItemRef(t *testing.T) {
+	client := openax.New()
+
+	petSchema := &openapi3.SchemaRef{Value: openapi3.NewObjectSchema()}
+	listPets := &openapi3.Operation{
+		Tags:      []string{"pets"},
+		Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Ref: "#/components/responses/PetList"})),
+	}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: "Path Item Refs", Version: "1.0.0"},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/pets", &openapi3.PathItem{Get: listPets}),
+			openapi3.WithPath("/animals", &openapi3.PathItem{Ref: "#/paths/~1pets"}),
+			openapi3.WithPath("/owners", &openapi3.PathItem{Get: &openapi3.Operation{Tags: []string{"owners"}}}),
+		),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{"Pet": petSchema},
+			Responses: openapi3.ResponseBodies{
+				"PetList": {Value: openapi3.NewResponse().WithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/Pet"})},
+			},
+		},
+	}
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pets"}, PruneComponents: true})
+	require.NoError(t, err, "Filter should not fail")
+
+	animals := filtered.Paths.Value("/animals")
+	require.NotNil(t, animals, "Referenced path item should be matched by tag")
+	assert.Same(t, listPets, animals.Get)
+	assert.Nil(t, filtered.Paths.Value("/owners"))
+	assert.Contains(t, filtered.Components.Responses, "PetList")
+	assert.Contains(t, filtered.Components.Schemas, "Pet")
+
+	t.Run("missing target", func(t *testing.T) {
+		doc.Paths.Set("/animals", &openapi3.PathItem{Ref: "#/paths/~1missing"})
+		_, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pets"}})
+		var notFound *openax.ComponentNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "/missing", notFound.Name)
+	})
+}
+
+func TestManifest(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	manifest := openax.Manifest(doc, openax.FilterOptions{Tags: []string{"store"}})
+	require.NotNil(t, manifest)
+	assert.Equal(t, []string{"Order"}, manifest[openax.ComponentSchemas])
+	assert.NotContains(t, manifest, openax.ComponentParameters, "Empty categories should be omitted")
+
+	manifest = openax.Manifest(doc, openax.FilterOptions{Tags: []string{"pet"}, NoPrune: []string{openax.ComponentSchemas}})
+	assert.Equal(t, []string{"ApiResponse", "Category", "Pet", "Tag"}, manifest[openax.ComponentSchemas])
+
+	assert.Nil(t, openax.Manifest(doc, openax.FilterOptions{MaxPathsPerTag: -1}))
+}
+
+func TestFilterDefaultOnlyResponses(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Default Responses
+  version: 1.0.0
+paths:
+  /events:
+    post:
+      tags: [events]
+      operationId: createEvent
+      responses:
+        default:
+          description: Any outcome
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Event'
+  /alerts:
+    post:
+      tags: [alerts]
+      responses:
+        default:
+          $ref: '#/components/responses/Problem'
+  /health:
+    get:
+      tags: [health]
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Event:
+      type: object
+    Problem:
+      type: object
+  responses:
+    Problem:
+      description: Error
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Problem'
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	testCases := []struct {
+		name      string
+		opts      openax.FilterOptions
+		paths     []string
+		schemas   []string
+		responses []string
+	}{
+		{
+			name:    "tag filter",
+			opts:    openax.FilterOptions{Tags: []string{"events"}},
+			paths:   []string{"/events"},
+			schemas: []string{"Event"},
+		},
+		{
+			name:      "method filter",
+			opts:      openax.FilterOptions{Operations: []string{"post"}},
+			paths:     []string{"/alerts", "/events"},
+			schemas:   []string{"Event", "Problem"},
+			responses: []string{"Problem"},
+		},
+		{
+			name:      "schema filter",
+			opts:      openax.FilterOptions{Schemas: []string{"Problem"}},
+			paths:     []string{"/alerts"},
+			schemas:   []string{"Problem"},
+			responses: []string{"Problem"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.PruneComponents = true
+			filtered, err := client.Filter(doc, tc.opts)
+			require.NoError(t, err, "Filter should not fail")
+
+			assert.ElementsMatch(t, tc.paths, filtered.Paths.InMatchingOrder())
+			assert.ElementsMatch(t, tc.schemas, slices.Collect(maps.Keys(filtered.Components.Schemas)))
+			assert.ElementsMatch(t, tc.responses, slices.Collect(maps.Keys(filtered.Components.Responses)))
+			for _, path := range tc.paths {
+				assert.NotNil(t, filtered.Paths.Value(path).Post.Responses.Default(), "default response of %s should be kept", path)
+			}
+		})
+	}
+}
+
+func TestFilterVersionSuffix(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+	version := doc.Info.Version
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"store"}, VersionSuffix: "+public"})
+	require.NoError(t, err, "Filter should not fail")
+
+	assert.Equal(t, version+"+public", filtered.Info.Version)
+	assert.Equal(t, doc.Info.Title, filtered.Info.Title)
+	assert.Equal(t, version, doc.Info.Version, "Source info should not be modified")
+}
+
+func TestFilterHasParameters(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Tenants
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      parameters:
+        - name: tenantId
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+    post:
+      responses:
+        "201":
+          description: Created
+  /orders:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/TenantId'
+      responses:
+        "200":
+          description: OK
+  /tenants/{tenantId}/settings:
+    parameters:
+      - name: tenantId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: OK
+  /health:
+    get:
+      parameters:
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    TenantId:
+      name: tenantId
+      in: header
+      schema:
+        type: string
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{HasParameters: []string{"tenantId"}})
+	require.NoError(t, err, "Filter should not fail")
+
+	assert.ElementsMatch(t, []string{"/users", "/orders", "/tenants/{tenantId}/settings"}, filtered.Paths.InMatchingOrder())
+	users := filtered.Paths.Value("/users")
+	assert.NotNil(t, users.Get)
+	assert.Nil(t, users.Post, "Operations without the parameter should be excluded")
+	assert.Contains(t, filtered.Components.Parameters, "TenantId")
+
+	t.Run("combined with method filter", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			HasParameters: []string{"tenantId", "verbose"},
+			Operations:    []string{"post"},
+		})
+		require.NoError(t, err, "Filter should not fail")
+		assert.Empty(t, filtered.Paths.Map())
+	})
+}
+
+func TestFilterEmptyPaths(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Empty Paths
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{Paths: []string{"/pets"}})
+	require.NoError(t, err, "Filter should not fail")
+	assert.Equal(t, []string{"/pets"}, filtered.Paths.InMatchingOrder(), "Paths without operations should be dropped")
+
+	t.Run("keep empty paths", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{Paths: []string{"/pets"}, KeepEmptyPaths: true})
+		require.NoError(t, err, "Filter should not fail")
+		assert.ElementsMatch(t, []string{"/pets", "/pets/{petId}"}, filtered.Paths.InMatchingOrder())
+	})
+}
+
+func TestFilterPathLevelParameterRefs(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Path Parameters
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: OK
+    delete:
+      operationId: deletePet
+      responses:
+        "204":
+          description: No Content
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/PetIdentifier'
+  schemas:
+    PetIdentifier:
+      allOf:
+        - $ref: '#/components/schemas/UUID'
+    UUID:
+      type: string
+      format: uuid
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	for name, opts := range map[string]openax.FilterOptions{
+		"whole path":        {Paths: []string{"/pets/{petId}"}, PruneComponents: true},
+		"matched operation": {Operations: []string{"getPet"}, PruneComponents: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			filtered, err := client.Filter(doc, opts)
+			require.NoError(t, err, "Filter should not fail")
+
+			pathItem := filtered.Paths.Value("/pets/{petId}")
+			require.NotNil(t, pathItem)
+			require.Len(t, pathItem.Parameters, 1, "Path-level parameters should be retained")
+			assert.Contains(t, filtered.Components.Parameters, "PetId")
+			assert.ElementsMatch(t, []string{"PetIdentifier", "UUID"}, slices.Collect(maps.Keys(filtered.Components.Schemas)),
+				"The parameter's schema and the schemas it references should be retained")
+			assert.NoError(t, client.Validate(filtered))
+		})
+	}
+}
+
+func TestFilterRequireRequestBody(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Writes
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        "201":
+          description: Created
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/PetPatch'
+      responses:
+        "200":
+          description: OK
+  /orders:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "201":
+          description: Created
+components:
+  requestBodies:
+    NewPet:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+    PetPatch:
+      type: object
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{RequireRequestBody: true, PruneComponents: true})
+	require.NoError(t, err, "Filter should not fail")
+
+	assert.ElementsMatch(t, []string{"/pets", "/orders"}, filtered.Paths.InMatchingOrder())
+	pets := filtered.Paths.Value("/pets")
+	assert.NotNil(t, pets.Post)
+	assert.Nil(t, pets.Get, "Operations without a request body should be excluded")
+	assert.Nil(t, pets.Put, "Operations with an optional request body should be excluded")
+	assert.NotNil(t, filtered.Paths.Value("/orders").Post)
+	assert.Contains(t, filtered.Components.RequestBodies, "NewPet")
+	assert.Equal(t, []string{"Pet"}, slices.Collect(maps.Keys(filtered.Components.Schemas)))
+}
+
+func TestFilterOperationSelectors(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	filtered, err := client.Filter(doc, openax.FilterOptions{
+		OperationSelectors: []openax.OperationSelector{
+			{Method: "GET", Path: "/pet/{petId}"},
+			{Method: "post", Path: "/store/order"},
+		},
+		PruneComponents: true,
+	})
+	require.NoError(t, err, "Filter should not fail")
+
+	assert.ElementsMatch(t, []string{"/pet/{petId}", "/store/order"}, filtered.Paths.InMatchingOrder())
+	assert.Len(t, filtered.Paths.Value("/pet/{petId}").Operations(), 1)
+	assert.NotNil(t, filtered.Paths.Value("/pet/{petId}").Get)
+	assert.NotNil(t, filtered.Paths.Value("/store/order").Post)
+	assert.ElementsMatch(t, []string{"Pet", "Category", "Tag", "Order"}, slices.Collect(maps.Keys(filtered.Components.Schemas)))
+
+	t.Run("not combined with other filters", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{
+			OperationSelectors: []openax.OperationSelector{{Method: "GET", Path: "/pet/{petId}"}},
+			Tags:               []string{"user"},
+		})
+		require.NoError(t, err, "Filter should not fail")
+		assert.NotNil(t, filtered.Paths.Value("/pet/{petId}"))
+		assert.NotNil(t, filtered.Paths.Value("/user/login"))
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		_, err := client.Filter(doc, openax.FilterOptions{
+			OperationSelectors: []openax.OperationSelector{{Method: "PATCH", Path: "/pet"}},
+		})
+		assert.ErrorContains(t, err, "no operation matches selector PATCH /pet")
+	})
+}
+
+func TestFilterWithoutPaths(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.1.0
+info:
+  title: Webhooks Only
+  version: 1.0.0
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+`))
+	require.NoError(t, err, "Failed to load spec")
+	require.Nil(t, doc.Paths)
+
+	for _, opts := range []openax.FilterOptions{
+		{},
+		{Tags: []string{"pets"}, PruneComponents: true},
+		{Schemas: []string{"Pet"}},
+		{HasParameters: []string{"petId"}, IncludeParentPaths: true},
+		{MaxPathsPerTag: 1, FollowLinks: true},
+	} {
+		filtered, err := client.Filter(doc, opts)
+		require.NoError(t, err, "Filter should not fail for %+v", opts)
+		require.NotNil(t, filtered.Paths)
+		assert.Zero(t, filtered.Paths.Len())
+	}
+
+	_, report, err := client.FilterWithReport(doc, openax.FilterOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Warnings, 1, "Dropping the webhooks should be reported")
+	assert.Equal(t, "webhooks", report.Warnings[0].Location.Path)
+	assert.Contains(t, report.Warnings[0].Message, "webhooks are not supported")
+}
+
+func TestFilterIndependence(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+	schemaNames := slices.Sorted(maps.Keys(doc.Components.Schemas))
+
+	t.Run("filtering twice", func(t *testing.T) {
+		doc.Components.Headers = openapi3.Headers{"X-Rate-Limit": {Value: &openapi3.Header{}}}
+
+		before, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pet"}})
+		require.NoError(t, err, "Filter should not fail")
+		pruned, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"store"}, PruneComponents: true})
+		require.NoError(t, err, "Filter should not fail")
+		after, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pet"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Equal(t, []string{"Order"}, slices.Sorted(maps.Keys(pruned.Components.Schemas)))
+		assert.Equal(t, slices.Sorted(maps.Keys(before.Components.Schemas)), slices.Sorted(maps.Keys(after.Components.Schemas)))
+		assert.Equal(t, schemaNames, slices.Sorted(maps.Keys(doc.Components.Schemas)))
+
+		pruned.Components.Headers["X-Injected"] = &openapi3.HeaderRef{}
+		delete(pruned.Components.Headers, "X-Rate-Limit")
+		assert.Equal(t, []string{"X-Rate-Limit"}, slices.Collect(maps.Keys(doc.Components.Headers)))
+		assert.Equal(t, []string{"X-Rate-Limit"}, slices.Collect(maps.Keys(after.Components.Headers)))
+	})
+
+	t.Run("concurrent filters", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for _, prune := range []bool{true, false, true, false} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pet"}, PruneComponents: prune})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, schemaNames, slices.Sorted(maps.Keys(doc.Components.Schemas)))
+	})
+
+	t.Run("deep copy", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{Tags: []string{"pet"}, PruneComponents: true, DeepCopy: true})
+		require.NoError(t, err, "Filter should not fail")
+
+		pet := filtered.Components.Schemas["Pet"].Value
+		require.NotNil(t, pet, "References should be resolved in the copy")
+		assert.NotSame(t, doc.Components.Schemas["Pet"].Value, pet)
+
+		pet.Description = "changed"
+		filtered.Paths.Value("/pet").Put.Summary = "changed"
+		assert.NotEqual(t, "changed", doc.Components.Schemas["Pet"].Value.Description)
+		assert.NotEqual(t, "changed", doc.Paths.Value("/pet").Put.Summary)
+
+		// The copy resolves references just like the loaded source
+		category := pet.Properties["category"]
+		assert.Equal(t, "#/components/schemas/Category", category.Ref)
+		assert.NotNil(t, category.Value)
+	})
+
+}
+
+func TestFilterBytes(t *testing.T) {
+	client := openax.New()
+
+	data, err := os.ReadFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to read spec")
+
+	t.Run("json output", func(t *testing.T) {
+		out, err := client.FilterBytes(data, openax.FilterOptions{Tags: []string{"users"}}, "json")
+		require.NoError(t, err, "FilterBytes should not fail")
+		require.True(t, json.Valid(out), "Output should be valid JSON")
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(out, &spec))
+		paths, ok := spec["paths"].(map[string]any)
+		require.True(t, ok, "Output should contain paths")
+		assert.Contains(t, paths, "/users")
+		assert.NotContains(t, paths, "/posts")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := client.FilterBytes(data, openax.FilterOptions{}, "toml")
+
+		var formatErr openax.UnsupportedFormatError
+		assert.ErrorAs(t, err, &formatErr)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := client.FilterBytes([]byte("not: [valid"), openax.FilterOptions{}, "json")
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterResolveServerVariables(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Regional API
+  version: 1.0.0
+servers:
+  - url: https://{region}.api.example.com
+    variables:
+      region:
+        default: us
+        enum: [us, eu]
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("substitutes defaults", func(t *testing.T) {
+		filtered, err := client.Filter(doc, openax.FilterOptions{ResolveServerVariables: true})
+		require.NoError(t, err, "Filter should not fail")
+
+		require.Len(t, filtered.Servers, 1)
+		assert.Equal(t, "https://us.api.example.com", filtered.Servers[0].URL)
+		assert.Empty(t, filtered.Servers[0].Variables)
+
+		// The source document is left intact
+		assert.Equal(t, "https://{region}.api.example.com", doc.Servers[0].URL)
+		assert.Contains(t, doc.Servers[0].Variables, "region")
+	})
+
+	t.Run("variable without default", func(t *testing.T) {
+		noDefault := *doc
+		noDefault.Servers = openapi3.Servers{{
+			URL:       "https://{region}.api.example.com",
+			Variables: map[string]*openapi3.ServerVariable{"region": {}},
+		}}
+
+		_, err := client.Filter(&noDefault, openax.FilterOptions{ResolveServerVariables: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `server variable "region" has no default`)
+	})
+}
+
+func TestHash(t *testing.T) {
+	spec := []byte(`openapi: 3.0.3
+info:
+  title: Hash API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`)
+
+	// Separate clients so each load produces an independent document
+	first, err := openax.New().LoadFromData(spec)
+	require.NoError(t, err, "Failed to load spec")
+	second, err := openax.New().LoadFromData(spec)
+	require.NoError(t, err, "Failed to load spec")
+
+	firstHash, err := openax.Hash(first)
+	require.NoError(t, err)
+	secondHash, err := openax.Hash(second)
+	require.NoError(t, err)
+
+	assert.Len(t, firstHash, 64, "Expected a hex-encoded SHA-256 digest")
+	assert.Equal(t, firstHash, secondHash, "Identical specs should hash equal")
+
+	second.Components.Schemas["Pet"].Value.Properties["name"].Value.Type = &openapi3.Types{"integer"}
+	changedHash, err := openax.Hash(second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstHash, changedHash, "A changed property should change the hash")
+
+	// Integers above 2^53 differ only beyond float64 precision
+	first.Components.Schemas["Pet"].Value.Properties["id"].Value.Example = int64(9007199254740992)
+	second.Components.Schemas["Pet"].Value.Properties["id"].Value.Example = int64(9007199254740993)
+	second.Components.Schemas["Pet"].Value.Properties["name"].Value.Type = &openapi3.Types{"string"}
+	firstHash, err = openax.Hash(first)
+	require.NoError(t, err)
+	secondHash, err = openax.Hash(second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstHash, secondHash, "Large integers should not collapse")
+}
+
+func TestValidateDir(t *testing.T) {
+	dir := t.TempDir()
+	spec, err := os.ReadFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err)
+
+	nested := filepath.Join(dir, "nested")
+	require.NoError(t, os.Mkdir(nested, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "valid.yaml"), spec, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.json"), []byte(`{"openapi": "3.0.3", "paths": {}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a spec"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "nested.yml"), spec, 0600))
+
+	t.Run("top level only", func(t *testing.T) {
+		results := openax.ValidateDir(dir, false)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, filepath.Join(dir, "invalid.json"), results[0].Path)
+		assert.False(t, results[0].Valid())
+		assert.Equal(t, filepath.Join(dir, "valid.yaml"), results[1].Path)
+		assert.True(t, results[1].Valid())
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		results := openax.ValidateDir(dir, true)
+		require.Len(t, results, 3)
+		assert.Equal(t, filepath.Join(nested, "nested.yml"), results[1].Path)
+		assert.True(t, results[1].Valid())
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		results := openax.ValidateDir(filepath.Join(dir, "missing"), true)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Valid())
+	})
+}
+
 func TestLoadAndFilter(t *testing.T) {
 	client := openax.New()
 
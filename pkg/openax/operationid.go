@@ -0,0 +1,189 @@
+package openax
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OperationIDCase selects the naming convention applied to retained operationIds.
+type OperationIDCase string
+
+// Supported operationId naming conventions.
+const (
+	OperationIDCasePreserve OperationIDCase = ""      // Keep operationIds unchanged
+	OperationIDCaseCamel    OperationIDCase = "camel" // getPetById
+	OperationIDCaseSnake    OperationIDCase = "snake" // get_pet_by_id
+	OperationIDCaseKebab    OperationIDCase = "kebab" // get-pet-by-id
+)
+
+// normalizeOperationIDs rewrites the operationIds of the filtered spec to the given
+// convention and updates links.operationId references to match.
+func normalizeOperationIDs(filtered *openapi3.T, idCase OperationIDCase) error {
+	if idCase == OperationIDCasePreserve {
+		return nil
+	}
+
+	// Build the rename map and detect collisions
+	renames := make(map[string]string)
+	owners := make(map[string]string)
+	for _, path := range filtered.Paths.InMatchingOrder() {
+		for _, operation := range filtered.Paths.Value(path).Operations() {
+			if operation == nil || operation.OperationID == "" {
+				continue
+			}
+			renamed, err := convertOperationID(operation.OperationID, idCase)
+			if err != nil {
+				return err
+			}
+			if owner, ok := owners[renamed]; ok && owner != operation.OperationID {
+				return fmt.Errorf("operationIds %q and %q both normalize to %q", owner, operation.OperationID, renamed)
+			}
+			owners[renamed] = operation.OperationID
+			renames[operation.OperationID] = renamed
+		}
+	}
+
+	for path, pathItem := range filtered.Paths.Map() {
+		copied := *pathItem
+		for method, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			op := *operation
+			if renamed, ok := renames[operation.OperationID]; ok {
+				op.OperationID = renamed
+			}
+			if operation.Responses != nil {
+				responses := &openapi3.Responses{Extensions: operation.Responses.Extensions}
+				for status, response := range operation.Responses.Map() {
+					responses.Set(status, renameResponseLinks(response, renames))
+				}
+				op.Responses = responses
+			}
+			copied.SetOperation(method, &op)
+		}
+		filtered.Paths.Set(path, &copied)
+	}
+
+	if filtered.Components != nil {
+		for name, response := range filtered.Components.Responses {
+			filtered.Components.Responses[name] = renameResponseLinks(response, renames)
+		}
+		filtered.Components.Links = renameLinks(filtered.Components.Links, renames)
+	}
+
+	return nil
+}
+
+// renameResponseLinks returns the response with its link targets renamed
+func renameResponseLinks(response *openapi3.ResponseRef, renames map[string]string) *openapi3.ResponseRef {
+	// Referenced components are renamed where they are defined
+	if response == nil || response.Ref != "" || response.Value == nil || len(response.Value.Links) == 0 {
+		return response
+	}
+	value := *response.Value
+	value.Links = renameLinks(response.Value.Links, renames)
+
+	copied := *response
+	copied.Value = &value
+	return &copied
+}
+
+// renameLinks returns a copy of links with operationId targets renamed
+func renameLinks(links openapi3.Links, renames map[string]string) openapi3.Links {
+	if links == nil {
+		return nil
+	}
+	renamed := maps.Clone(links)
+	for name, link := range links {
+		if link == nil || link.Ref != "" || link.Value == nil {
+			continue
+		}
+		target, ok := renames[link.Value.OperationID]
+		if !ok || target == link.Value.OperationID {
+			continue
+		}
+		value := *link.Value
+		value.OperationID = target
+
+		copied := *link
+		copied.Value = &value
+		renamed[name] = &copied
+	}
+	return renamed
+}
+
+// convertOperationID converts an operationId to the given naming convention.
+// Non-identifier characters such as the "/" and ":" of gRPC-style ids act as
+// word separators (e.g., "PetService/GetPet" -> "pet_service_get_pet").
+func convertOperationID(operationID string, idCase OperationIDCase) (string, error) {
+	words := splitIdentifierWords(operationID)
+
+	switch idCase {
+	case OperationIDCasePreserve:
+		return operationID, nil
+	case OperationIDCaseCamel, OperationIDCaseSnake, OperationIDCaseKebab:
+		// Leave ids without any letters or digits alone rather than blanking them
+		if len(words) == 0 {
+			return operationID, nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported operationId case: %q", idCase)
+	}
+
+	switch idCase {
+	case OperationIDCaseCamel:
+		var b strings.Builder
+		for i, word := range words {
+			if i == 0 {
+				b.WriteString(strings.ToLower(word))
+				continue
+			}
+			runes := []rune(strings.ToLower(word))
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+		return b.String(), nil
+	case OperationIDCaseSnake:
+		return strings.ToLower(strings.Join(words, "_")), nil
+	default:
+		return strings.ToLower(strings.Join(words, "-")), nil
+	}
+}
+
+// splitIdentifierWords splits an identifier on separators and case boundaries,
+// keeping acronyms together (e.g., "getHTTPStatus" -> get, HTTP, Status)
+func splitIdentifierWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
@@ -0,0 +1,112 @@
+package openax
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationID(t *testing.T) {
+	testCases := []struct {
+		input    string
+		idCase   OperationIDCase
+		expected string
+	}{
+		{"get_pet_by_id", OperationIDCaseCamel, "getPetById"},
+		{"getPetById", OperationIDCaseSnake, "get_pet_by_id"},
+		{"getPetById", OperationIDCaseKebab, "get-pet-by-id"},
+		{"get-pet-by-id", OperationIDCaseCamel, "getPetById"},
+		{"getHTTPStatus", OperationIDCaseSnake, "get_http_status"},
+		{"list_v2_pets", OperationIDCaseCamel, "listV2Pets"},
+		{"get_pet_by_id", OperationIDCasePreserve, "get_pet_by_id"},
+		{"PetService/GetPet", OperationIDCasePreserve, "PetService/GetPet"},
+		{"PetService/GetPet", OperationIDCaseSnake, "pet_service_get_pet"},
+		{"PetService/GetPet", OperationIDCaseCamel, "petServiceGetPet"},
+		{"pets:list", OperationIDCaseKebab, "pets-list"},
+		{"::", OperationIDCaseCamel, "::"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input+"_"+string(tc.idCase), func(t *testing.T) {
+			result, err := convertOperationID(tc.input, tc.idCase)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestOperationIDCaseNormalization(t *testing.T) {
+	t.Run("rename operation and link", func(t *testing.T) {
+		doc := createTestSpecWithSnakeCaseOperations()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			OperationIDCase: OperationIDCaseCamel,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "getPetById", filteredDoc.Paths.Value("/pets/{id}").Get.OperationID)
+		assert.Equal(t, "listPets", filteredDoc.Paths.Value("/pets").Get.OperationID)
+
+		link := filteredDoc.Paths.Value("/pets").Get.Responses.Value("200").Value.Links["pet"]
+		assert.Equal(t, "getPetById", link.Value.OperationID)
+
+		// The source document must not be modified
+		assert.Equal(t, "get_pet_by_id", doc.Paths.Value("/pets/{id}").Get.OperationID)
+		assert.Equal(t, "get_pet_by_id", doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Links["pet"].Value.OperationID)
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		doc := createTestSpecWithSnakeCaseOperations()
+		doc.Paths.Value("/pets").Post = &openapi3.Operation{
+			OperationID: "getPetById",
+			Responses:   &openapi3.Responses{},
+		}
+
+		_, err := applyFilter(doc, FilterOptions{
+			OperationIDCase: OperationIDCaseCamel,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported case", func(t *testing.T) {
+		_, err := applyFilter(createTestSpecWithSnakeCaseOperations(), FilterOptions{
+			OperationIDCase: "pascal",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func createTestSpecWithSnakeCaseOperations() *openapi3.T {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "Test API",
+			Version: "1.0.0",
+		},
+		Paths:      &openapi3.Paths{},
+		Components: &openapi3.Components{},
+	}
+
+	description := okDescription
+	list := &openapi3.Operation{OperationID: "list_pets", Responses: &openapi3.Responses{}}
+	list.Responses.Set("200", &openapi3.ResponseRef{
+		Value: &openapi3.Response{
+			Description: &description,
+			Links: openapi3.Links{
+				"pet": &openapi3.LinkRef{Value: &openapi3.Link{OperationID: "get_pet_by_id"}},
+			},
+		},
+	})
+
+	get := &openapi3.Operation{OperationID: "get_pet_by_id", Responses: &openapi3.Responses{}}
+	get.Responses.Set("200", &openapi3.ResponseRef{
+		Value: &openapi3.Response{Description: &description},
+	})
+
+	doc.Paths.Set("/pets", &openapi3.PathItem{Get: list})
+	doc.Paths.Set("/pets/{id}", &openapi3.PathItem{Get: get})
+
+	return doc
+}
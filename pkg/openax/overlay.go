@@ -0,0 +1,154 @@
+package openax
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Overlay applies an overlay specification on top of a base specification.
+//
+// This loosely follows the OpenAPI Overlay idea for environment-specific specs:
+//   - servers: the overlay's servers replace the base servers when present
+//   - paths: new paths are added; for existing paths the overlay's operations
+//     replace or add to the base operations
+//   - components and tags: entries are merged by name
+//
+// The overlay wins on every conflict. Neither input is modified; the result
+// shares unchanged objects with them.
+//
+// Example:
+//
+//	base, _ := client.LoadFromFile("api.yaml")
+//	prod, _ := client.LoadFromFile("overlay.prod.yaml")
+//	merged, err := openax.Overlay(base, prod)
+func Overlay(base, overlay *openapi3.T) (*openapi3.T, error) {
+	if base == nil {
+		return nil, errors.New("overlay: base specification is nil")
+	}
+	if overlay == nil {
+		return nil, errors.New("overlay: overlay specification is nil")
+	}
+
+	merged := *base
+	merged.Extensions = mergeMaps(base.Extensions, overlay.Extensions)
+
+	if len(overlay.Servers) > 0 {
+		merged.Servers = overlay.Servers
+	}
+
+	merged.Tags = mergeTags(base.Tags, overlay.Tags)
+	merged.Paths = mergePaths(base.Paths, overlay.Paths)
+	merged.Components = mergeComponents(base.Components, overlay.Components)
+
+	return &merged, nil
+}
+
+// mergePaths returns a new Paths with the overlay's path items applied on top of the base
+func mergePaths(base, overlay *openapi3.Paths) *openapi3.Paths {
+	merged := &openapi3.Paths{}
+	if base != nil {
+		merged.Extensions = base.Extensions
+		for path, pathItem := range base.Map() {
+			merged.Set(path, pathItem)
+		}
+	}
+	if overlay == nil {
+		return merged
+	}
+
+	merged.Extensions = mergeMaps(merged.Extensions, overlay.Extensions)
+	for path, overlayItem := range overlay.Map() {
+		baseItem := merged.Value(path)
+		if baseItem == nil || overlayItem == nil {
+			merged.Set(path, overlayItem)
+			continue
+		}
+		merged.Set(path, mergePathItem(baseItem, overlayItem))
+	}
+	return merged
+}
+
+// mergePathItem returns a copy of the base path item with the overlay's operations
+// and non-empty fields applied
+func mergePathItem(base, overlay *openapi3.PathItem) *openapi3.PathItem {
+	merged := *base
+	merged.Extensions = mergeMaps(base.Extensions, overlay.Extensions)
+
+	if overlay.Summary != "" {
+		merged.Summary = overlay.Summary
+	}
+	if overlay.Description != "" {
+		merged.Description = overlay.Description
+	}
+	if len(overlay.Servers) > 0 {
+		merged.Servers = overlay.Servers
+	}
+	if len(overlay.Parameters) > 0 {
+		merged.Parameters = overlay.Parameters
+	}
+
+	for method, operation := range overlay.Operations() {
+		merged.SetOperation(method, operation)
+	}
+	return &merged
+}
+
+// mergeComponents merges each component category by name
+func mergeComponents(base, overlay *openapi3.Components) *openapi3.Components {
+	if overlay == nil {
+		return base
+	}
+	if base == nil {
+		return overlay
+	}
+
+	merged := *base
+	merged.Extensions = mergeMaps(base.Extensions, overlay.Extensions)
+	merged.Schemas = mergeMaps(base.Schemas, overlay.Schemas)
+	merged.Parameters = mergeMaps(base.Parameters, overlay.Parameters)
+	merged.Headers = mergeMaps(base.Headers, overlay.Headers)
+	merged.RequestBodies = mergeMaps(base.RequestBodies, overlay.RequestBodies)
+	merged.Responses = mergeMaps(base.Responses, overlay.Responses)
+	merged.SecuritySchemes = mergeMaps(base.SecuritySchemes, overlay.SecuritySchemes)
+	merged.Examples = mergeMaps(base.Examples, overlay.Examples)
+	merged.Links = mergeMaps(base.Links, overlay.Links)
+	merged.Callbacks = mergeMaps(base.Callbacks, overlay.Callbacks)
+	return &merged
+}
+
+// mergeTags merges tags by name, keeping base order and appending new tags
+func mergeTags(base, overlay openapi3.Tags) openapi3.Tags {
+	if len(overlay) == 0 {
+		return base
+	}
+
+	merged := make(openapi3.Tags, len(base), len(base)+len(overlay))
+	copy(merged, base)
+	for _, tag := range overlay {
+		replaced := false
+		for i, existing := range merged {
+			if existing.Name == tag.Name {
+				merged[i] = tag
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// mergeMaps returns a new map with the overlay entries applied on top of the base
+func mergeMaps[M ~map[string]V, V any](base, overlay M) M {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := make(M, len(base)+len(overlay))
+	maps.Copy(merged, base)
+	maps.Copy(merged, overlay)
+	return merged
+}
@@ -0,0 +1,75 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlay(t *testing.T) {
+	client := openax.New()
+
+	base, err := client.LoadFromFile("../../testdata/specs/simple.yaml")
+	require.NoError(t, err, "Failed to load base spec")
+	baseServers := len(base.Servers)
+
+	overlay, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Production overlay
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /health:
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: OK
+  /users:
+    delete:
+      operationId: deleteUsers
+      responses:
+        '204':
+          description: Deleted
+`))
+	require.NoError(t, err, "Failed to load overlay spec")
+
+	merged, err := openax.Overlay(base, overlay)
+	require.NoError(t, err, "Overlay should not fail")
+
+	t.Run("server replaced", func(t *testing.T) {
+		require.Len(t, merged.Servers, 1)
+		assert.Equal(t, "https://api.example.com", merged.Servers[0].URL)
+	})
+
+	t.Run("path added", func(t *testing.T) {
+		require.NotNil(t, merged.Paths.Value("/health"))
+		assert.Equal(t, "health", merged.Paths.Value("/health").Get.OperationID)
+	})
+
+	t.Run("operations merged into existing path", func(t *testing.T) {
+		users := merged.Paths.Value("/users")
+		require.NotNil(t, users)
+		assert.NotNil(t, users.Get, "Base operation should be kept")
+		assert.NotNil(t, users.Delete, "Overlay operation should be added")
+	})
+
+	t.Run("base unchanged", func(t *testing.T) {
+		assert.Len(t, base.Servers, baseServers)
+		assert.Nil(t, base.Paths.Value("/health"))
+		assert.Nil(t, base.Paths.Value("/users").Delete)
+		assert.Equal(t, "Simple Test API", merged.Info.Title, "Base info should be kept")
+	})
+
+	t.Run("merged spec is valid", func(t *testing.T) {
+		assert.NoError(t, client.Validate(merged))
+	})
+
+	t.Run("nil input", func(t *testing.T) {
+		_, err := openax.Overlay(nil, overlay)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package openax
+
+import (
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// findOperationsWithParameters returns the operations that declare a parameter with
+// any of the given names, either themselves or through their path item
+func findOperationsWithParameters(doc *openapi3.T, names []string) (map[*openapi3.Operation]bool, error) {
+	declares := func(params openapi3.Parameters) bool {
+		return slices.ContainsFunc(params, func(param *openapi3.ParameterRef) bool {
+			value := resolveParameter(doc, param)
+			return value != nil && slices.Contains(names, value.Name)
+		})
+	}
+
+	matched := make(map[*openapi3.Operation]bool)
+	for path, pathItem := range doc.Paths.Map() {
+		pathItem, err := resolvePathItem(doc, path, pathItem)
+		if err != nil {
+			return nil, err
+		}
+		if pathItem == nil {
+			continue
+		}
+
+		pathLevel := declares(pathItem.Parameters)
+		for _, operation := range pathItem.Operations() {
+			if operation != nil && (pathLevel || declares(operation.Parameters)) {
+				matched[operation] = true
+			}
+		}
+	}
+	return matched, nil
+}
+
+// resolveParameter returns the parameter value, looking up component references when
+// the loader has not populated the value
+func resolveParameter(doc *openapi3.T, paramRef *openapi3.ParameterRef) *openapi3.Parameter {
+	if paramRef == nil {
+		return nil
+	}
+	if paramRef.Value != nil {
+		return paramRef.Value
+	}
+	if paramRef.Ref != "" && doc.Components != nil {
+		if component, ok := doc.Components.Parameters[extractRefName(paramRef.Ref)]; ok && component != nil {
+			return component.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// pathItemRefPrefix is the only $ref target a path item can point to within an
+// OpenAPI 3.0 document, which has no components.pathItems section.
+const pathItemRefPrefix = "#/paths/"
+
+// resolvePathItem follows a path item's $ref to the path item it points at so its
+// operations take part in filtering. The loader already resolves references it can
+// reach, so this only handles path items whose operations were left unpopulated,
+// such as documents built in code.
+func resolvePathItem(doc *openapi3.T, path string, pathItem *openapi3.PathItem) (*openapi3.PathItem, error) {
+	visited := make(map[string]bool)
+	for pathItem != nil && pathItem.Ref != "" && len(pathItem.Operations()) == 0 {
+		ref := pathItem.Ref
+		location := createLocation(fmt.Sprintf("paths.%s.$ref", path))
+		if visited[ref] {
+			return nil, InvalidReferenceError{Ref: ref, Reason: "circular path item reference", Location: location}
+		}
+		visited[ref] = true
+
+		name, ok := strings.CutPrefix(ref, pathItemRefPrefix)
+		if !ok {
+			return nil, InvalidReferenceError{Ref: ref, Reason: "invalid format", Location: location}
+		}
+		name = unescapeJSONPointer(name)
+
+		target := doc.Paths.Value(name)
+		if target == nil {
+			return nil, &ComponentNotFoundError{Name: name, Type: "path item", Context: path, Location: location}
+		}
+		pathItem = target
+	}
+	return pathItem, nil
+}
+
+// unescapeJSONPointer decodes a single JSON pointer token, e.g. "~1pets" to "/pets".
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
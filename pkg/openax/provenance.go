@@ -0,0 +1,125 @@
+package openax
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// provenanceExtensionKey is the top-level extension recording how a spec was filtered
+	provenanceExtensionKey = "x-openax"
+
+	// modulePath is used to look up the tool version from build information
+	modulePath = "github.com/imtanmoy/openax"
+)
+
+// embedProvenance records the applied filters, tool version, and generation time
+// in the x-openax extension of the filtered spec
+func embedProvenance(filtered *openapi3.T, opts FilterOptions, now time.Time) {
+	filters := map[string]any{}
+	if len(opts.Paths) > 0 {
+		filters["paths"] = opts.Paths
+	}
+	if len(opts.Operations) > 0 {
+		filters["operations"] = opts.Operations
+	}
+	if len(opts.Tags) > 0 {
+		filters["tags"] = opts.Tags
+	}
+	if opts.TagDescriptionMatch != "" {
+		filters["tagDescriptionMatch"] = opts.TagDescriptionMatch
+	}
+	if len(opts.Schemas) > 0 {
+		filters["schemas"] = opts.Schemas
+	}
+	if len(opts.HasParameters) > 0 {
+		filters["hasParameters"] = opts.HasParameters
+	}
+	if opts.RequireRequestBody {
+		filters["requireRequestBody"] = true
+	}
+	if len(opts.OperationSelectors) > 0 {
+		filters["operationSelectors"] = opts.OperationSelectors
+	}
+	if len(opts.Keep) > 0 {
+		filters["keep"] = opts.Keep
+	}
+	if opts.MaxPathsPerTag > 0 {
+		filters["maxPathsPerTag"] = opts.MaxPathsPerTag
+	}
+	if opts.KeepEmptyPaths {
+		filters["keepEmptyPaths"] = true
+	}
+	if opts.KeepEmptyContent {
+		filters["keepEmptyContent"] = true
+	}
+	if opts.PruneComponents {
+		filters["pruneComponents"] = true
+	}
+	if opts.PruneSecuritySchemes {
+		filters["pruneSecuritySchemes"] = true
+	}
+	if len(opts.NoPrune) > 0 {
+		filters["noPrune"] = opts.NoPrune
+	}
+	if len(opts.StripExtensions) > 0 {
+		filters["stripExtensions"] = opts.StripExtensions
+	}
+	if opts.OperationIDCase != OperationIDCasePreserve {
+		filters["operationIdCase"] = string(opts.OperationIDCase)
+	}
+	if opts.FollowLinks {
+		filters["followLinks"] = true
+	}
+	if opts.IncludeParentPaths {
+		filters["includeParentPaths"] = true
+	}
+	if opts.StripUnusedTags != nil {
+		filters["stripUnusedTags"] = *opts.StripUnusedTags
+	}
+	if opts.ResolveServerVariables {
+		filters["resolveServerVariables"] = true
+	}
+	if opts.CanonicalResponseContentType != "" {
+		filters["canonicalResponseContentType"] = opts.CanonicalResponseContentType
+	}
+	if opts.VersionSuffix != "" {
+		filters["versionSuffix"] = opts.VersionSuffix
+	}
+
+	if filtered.Extensions == nil {
+		filtered.Extensions = make(map[string]any)
+	}
+	filtered.Extensions[provenanceExtensionKey] = map[string]any{
+		"version":     toolVersion(),
+		"generatedAt": now.UTC().Format(time.RFC3339),
+		"filters":     filters,
+	}
+}
+
+// toolVersion returns the openax module version from build information, or "dev"
+// when it is not available (e.g., local builds)
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+
+	version := info.Main.Version
+	if info.Main.Path != modulePath {
+		version = ""
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+				break
+			}
+		}
+	}
+
+	if version == "" || version == "(devel)" {
+		return "dev"
+	}
+	return version
+}
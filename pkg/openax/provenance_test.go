@@ -0,0 +1,52 @@
+package openax
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedProvenance(t *testing.T) {
+	t.Run("extension records filters", func(t *testing.T) {
+		doc := createTestSpecWithUnusedComponents()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Paths:           []string{"/users"},
+			Tags:            []string{"users"},
+			PruneComponents: true,
+			EmbedProvenance: true,
+		})
+		require.NoError(t, err)
+
+		data, err := json.Marshal(filteredDoc)
+		require.NoError(t, err)
+
+		var out struct {
+			Provenance struct {
+				Version     string         `json:"version"`
+				GeneratedAt string         `json:"generatedAt"`
+				Filters     map[string]any `json:"filters"`
+			} `json:"x-openax"`
+		}
+		require.NoError(t, json.Unmarshal(data, &out))
+
+		assert.NotEmpty(t, out.Provenance.Version)
+		_, err = time.Parse(time.RFC3339, out.Provenance.GeneratedAt)
+		assert.NoError(t, err, "generatedAt should be an RFC 3339 timestamp")
+
+		assert.Equal(t, []any{"/users"}, out.Provenance.Filters["paths"])
+		assert.Equal(t, []any{"users"}, out.Provenance.Filters["tags"])
+		assert.Equal(t, true, out.Provenance.Filters["pruneComponents"])
+		assert.NotContains(t, out.Provenance.Filters, "operations")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		filteredDoc, err := applyFilter(createTestSpecWithUnusedComponents(), FilterOptions{})
+		require.NoError(t, err)
+
+		assert.NotContains(t, filteredDoc.Extensions, provenanceExtensionKey)
+	})
+}
@@ -1,6 +1,7 @@
 package openax
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -48,6 +49,238 @@ func TestComponentPruningBasic(t *testing.T) {
 	})
 }
 
+func TestComponentPruningCategories(t *testing.T) {
+	t.Run("security schemes are kept by default", func(t *testing.T) {
+		doc := createTestSpecWithSecuritySchemes()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Paths:           []string{"/users"},
+			PruneComponents: true,
+		})
+
+		require.NoError(t, err)
+
+		assert.NotContains(t, filteredDoc.Components.Schemas, "UnusedSchema")
+		assert.Contains(t, filteredDoc.Components.SecuritySchemes, "ApiKey")
+		assert.Contains(t, filteredDoc.Components.SecuritySchemes, "BasicAuth")
+	})
+
+	t.Run("prune unused security schemes", func(t *testing.T) {
+		doc := createTestSpecWithSecuritySchemes()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Paths:                []string{"/users"},
+			PruneComponents:      true,
+			PruneSecuritySchemes: true,
+		})
+
+		require.NoError(t, err)
+
+		assert.NotContains(t, filteredDoc.Components.Schemas, "UnusedSchema")
+		assert.Contains(t, filteredDoc.Components.SecuritySchemes, "ApiKey")
+		assert.NotContains(t, filteredDoc.Components.SecuritySchemes, "BasicAuth")
+		// The source document must not be modified
+		assert.Contains(t, doc.Components.SecuritySchemes, "BasicAuth")
+	})
+
+	t.Run("prune schemas but keep all security schemes", func(t *testing.T) {
+		doc := createTestSpecWithSecuritySchemes()
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Paths:                []string{"/users"},
+			PruneComponents:      true,
+			PruneSecuritySchemes: true,
+			NoPrune:              []string{ComponentSecuritySchemes},
+		})
+
+		require.NoError(t, err)
+
+		assert.Contains(t, filteredDoc.Components.Schemas, "UsedSchema")
+		assert.NotContains(t, filteredDoc.Components.Schemas, "UnusedSchema")
+		assert.Contains(t, filteredDoc.Components.SecuritySchemes, "ApiKey")
+		assert.Contains(t, filteredDoc.Components.SecuritySchemes, "BasicAuth")
+	})
+
+	t.Run("prune unused oauth scopes", func(t *testing.T) {
+		doc := createTestSpecWithSecuritySchemes()
+		doc.Components.SecuritySchemes["OAuth"] = &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{
+				Type: "oauth2",
+				Flows: &openapi3.OAuthFlows{
+					ClientCredentials: &openapi3.OAuthFlow{
+						TokenURL: "https://auth.example.com/token",
+						Scopes: map[string]string{
+							"read:pets":   "Read pets",
+							"write:admin": "Administer everything",
+						},
+					},
+				},
+			},
+		}
+		doc.Paths.Value("/users").Get.Security = &openapi3.SecurityRequirements{
+			openapi3.SecurityRequirement{"OAuth": []string{"read:pets"}},
+		}
+
+		filteredDoc, err := applyFilter(doc, FilterOptions{
+			Paths:                []string{"/users"},
+			PruneComponents:      true,
+			PruneSecuritySchemes: true,
+		})
+
+		require.NoError(t, err)
+
+		require.Contains(t, filteredDoc.Components.SecuritySchemes, "OAuth")
+		scopes := filteredDoc.Components.SecuritySchemes["OAuth"].Value.Flows.ClientCredentials.Scopes
+		assert.Contains(t, scopes, "read:pets")
+		assert.NotContains(t, scopes, "write:admin")
+		// The source document must not be modified
+		assert.Contains(t, doc.Components.SecuritySchemes["OAuth"].Value.Flows.ClientCredentials.Scopes, "write:admin")
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		doc := createTestSpecWithSecuritySchemes()
+
+		_, err := applyFilter(doc, FilterOptions{
+			PruneComponents: true,
+			NoPrune:         []string{"widgets"},
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTransitiveUsageMatchesFixedPoint(t *testing.T) {
+	docs := map[string]*openapi3.T{
+		"chain":      createTestSpecWithSchemaChain(50),
+		"transitive": createTestSpecWithTransitiveReferences(),
+		"unused":     createTestSpecWithUnusedComponents(),
+		"api":        createTestAPISpec(20, 3),
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			seed := func() *ComponentUsage {
+				return &ComponentUsage{
+					Schemas:       map[string]bool{},
+					Parameters:    map[string]bool{},
+					RequestBodies: map[string]bool{},
+					Responses:     map[string]bool{},
+				}
+			}
+
+			// Seed with the first schema referenced by every path, as filtering would
+			expected, actual := seed(), seed()
+			for _, pathItem := range doc.Paths.Map() {
+				for _, operation := range pathItem.Operations() {
+					refs := make(map[string]bool)
+					require.NoError(t, processContentSchemas(operation.Responses.Value("200").Value.Content,
+						[]string{"application/json"}, refs))
+					for ref := range refs {
+						expected.Schemas[ref] = true
+						actual.Schemas[ref] = true
+					}
+				}
+			}
+
+			findTransitivelyUsedComponentsFixedPoint(doc, expected)
+			findTransitivelyUsedComponents(doc, actual)
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("pruned output", func(t *testing.T) {
+		filteredDoc, err := applyFilter(createTestSpecWithSchemaChain(50), FilterOptions{
+			Paths:           []string{"/chain"},
+			PruneComponents: true,
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, filteredDoc.Components.Schemas, 50)
+	})
+}
+
+// findTransitivelyUsedComponentsFixedPoint is the iterate-until-stable reference
+// implementation that findTransitivelyUsedComponents must agree with
+func findTransitivelyUsedComponentsFixedPoint(filtered *openapi3.T, usage *ComponentUsage) {
+	addRefs := func(schema *openapi3.SchemaRef) bool {
+		changed := false
+		refs := make(map[string]bool)
+		if err := extractSchemaReferences(schema, refs); err == nil {
+			for refName := range refs {
+				if !usage.Schemas[refName] {
+					usage.Schemas[refName] = true
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+
+	for {
+		changed := false
+		for schemaName := range usage.Schemas {
+			if schema, exists := filtered.Components.Schemas[schemaName]; exists && schema != nil {
+				changed = addRefs(schema) || changed
+			}
+		}
+		for paramName := range usage.Parameters {
+			if param, exists := filtered.Components.Parameters[paramName]; exists && param.Value != nil && param.Value.Schema != nil {
+				changed = addRefs(param.Value.Schema) || changed
+			}
+		}
+		for rbName := range usage.RequestBodies {
+			if rb, exists := filtered.Components.RequestBodies[rbName]; exists && rb.Value != nil {
+				for _, mediaType := range rb.Value.Content {
+					if mediaType.Schema != nil {
+						changed = addRefs(mediaType.Schema) || changed
+					}
+				}
+			}
+		}
+		for respName := range usage.Responses {
+			if resp, exists := filtered.Components.Responses[respName]; exists && resp.Value != nil {
+				for _, mediaType := range resp.Value.Content {
+					if mediaType.Schema != nil {
+						changed = addRefs(mediaType.Schema) || changed
+					}
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+}
+
+func TestComponentPruningComposition(t *testing.T) {
+	doc := createTestSpecWithComposition()
+
+	t.Run("transitive usage follows composition", func(t *testing.T) {
+		usage := &ComponentUsage{
+			Schemas:       map[string]bool{"Dog": true},
+			Parameters:    map[string]bool{},
+			RequestBodies: map[string]bool{},
+			Responses:     map[string]bool{},
+		}
+		findTransitivelyUsedComponents(doc, usage)
+
+		assert.Equal(t, map[string]bool{"Dog": true, "Base": true, "Owner": true, "Nested": true}, usage.Schemas)
+	})
+
+	t.Run("allOf base survives pruning", func(t *testing.T) {
+		filteredDoc, err := applyFilter(doc, FilterOptions{PruneComponents: true})
+		require.NoError(t, err)
+
+		schemas := filteredDoc.Components.Schemas
+		assert.Contains(t, schemas, "Dog")
+		assert.Contains(t, schemas, "Base", "allOf base should be kept")
+		assert.Contains(t, schemas, "Owner", "Schemas referenced from inline allOf members should be kept")
+		assert.Contains(t, schemas, "Nested", "Schemas referenced from nested composition should be kept")
+		assert.NotContains(t, schemas, "Unused")
+	})
+}
+
 // Helper functions to create test data
 
 func createTestSpecWithUnusedComponents() *openapi3.T {
@@ -189,3 +422,125 @@ func createTestSpecWithTransitiveReferences() *openapi3.T {
 
 	return doc
 }
+
+func createTestSpecWithSecuritySchemes() *openapi3.T {
+	doc := createTestSpecWithUnusedComponents()
+
+	doc.Components.SecuritySchemes = openapi3.SecuritySchemes{
+		"ApiKey": &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{Type: "apiKey", Name: "X-API-Key", In: "header"},
+		},
+		"BasicAuth": &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{Type: "http", Scheme: "basic"},
+		},
+	}
+
+	doc.Paths.Value("/users").Get.Security = &openapi3.SecurityRequirements{
+		openapi3.SecurityRequirement{"ApiKey": []string{}},
+	}
+
+	return doc
+}
+
+// createTestSpecWithSchemaChain creates a spec where Schema0 references Schema1,
+// which references Schema2, and so on, plus an equal number of unused schemas
+func createTestSpecWithSchemaChain(length int) *openapi3.T {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "Test API",
+			Version: "1.0.0",
+		},
+		Paths: &openapi3.Paths{},
+		Components: &openapi3.Components{
+			Schemas: make(openapi3.Schemas),
+		},
+	}
+
+	for i := 0; i < length; i++ {
+		schema := &openapi3.Schema{Type: &openapi3.Types{"object"}}
+		if i+1 < length {
+			schema.Properties = openapi3.Schemas{
+				"next": &openapi3.SchemaRef{Ref: fmt.Sprintf("#/components/schemas/Schema%d", i+1)},
+			}
+		}
+		doc.Components.Schemas[fmt.Sprintf("Schema%d", i)] = &openapi3.SchemaRef{Value: schema}
+		doc.Components.Schemas[fmt.Sprintf("Orphan%d", i)] = &openapi3.SchemaRef{
+			Value: &openapi3.Schema{Type: &openapi3.Types{"string"}},
+		}
+	}
+
+	description := okDescription
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: &openapi3.Responses{},
+		},
+	}
+
+	pathItem.Get.Responses.Set("200", &openapi3.ResponseRef{
+		Value: &openapi3.Response{
+			Description: &description,
+			Content: openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Schema: &openapi3.SchemaRef{
+						Ref: "#/components/schemas/Schema0",
+					},
+				},
+			},
+		},
+	})
+
+	doc.Paths.Set("/chain", pathItem)
+
+	return doc
+}
+
+// createTestSpecWithComposition creates a spec whose only operation references Dog,
+// which reaches its other schemas solely through allOf/oneOf composition
+func createTestSpecWithComposition() *openapi3.T {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "Composition API", Version: "1.0.0"},
+		Paths:   &openapi3.Paths{},
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"Dog": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					AllOf: openapi3.SchemaRefs{
+						{Ref: "#/components/schemas/Base"},
+						{Value: &openapi3.Schema{
+							Type: &openapi3.Types{"object"},
+							Properties: openapi3.Schemas{
+								"owner": {Ref: "#/components/schemas/Owner"},
+							},
+						}},
+					},
+					OneOf: openapi3.SchemaRefs{
+						{Value: &openapi3.Schema{
+							AllOf: openapi3.SchemaRefs{{Ref: "#/components/schemas/Nested"}},
+						}},
+					},
+				}},
+				"Base":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+				"Owner":  &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+				"Nested": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+				"Unused": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+			},
+		},
+	}
+
+	description := "Dog"
+	responses := openapi3.NewResponses()
+	responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{
+		Description: &description,
+		Content: openapi3.Content{
+			"application/json": &openapi3.MediaType{
+				Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Dog"},
+			},
+		},
+	}})
+	doc.Paths.Set("/dogs", &openapi3.PathItem{
+		Get: &openapi3.Operation{OperationID: "getDog", Responses: responses},
+	})
+
+	return doc
+}
@@ -0,0 +1,78 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Warning describes a non-fatal issue found while filtering.
+type Warning struct {
+	Message  string          // Human-readable description of the issue
+	Location *SourceLocation // Location of the issue in the source specification
+}
+
+// String returns a human-readable representation of the warning.
+func (w Warning) String() string {
+	if w.Location == nil {
+		return w.Message
+	}
+	return fmt.Sprintf("%s at %s", w.Message, w.Location.String())
+}
+
+// FilterReport describes the outcome of a filtering run beyond the filtered spec.
+type FilterReport struct {
+	// Warnings lists non-fatal issues, such as operations using undeclared tags.
+	Warnings []Warning
+
+	// RemovedOperations lists the operations of the source specification that the
+	// filtered specification no longer contains, ordered by path and method, e.g.
+	// for generating redirects. OperationIDs are those of the source.
+	RemovedOperations []OperationRef
+}
+
+// warn records a warning at the given location
+func (r *FilterReport) warn(location *SourceLocation, format string, args ...any) {
+	r.Warnings = append(r.Warnings, Warning{
+		Message:  fmt.Sprintf(format, args...),
+		Location: location,
+	})
+}
+
+// sortWarnings orders warnings by location so reports are stable across runs
+func (r *FilterReport) sortWarnings() {
+	slices.SortStableFunc(r.Warnings, func(a, b Warning) int {
+		return strings.Compare(a.String(), b.String())
+	})
+}
+
+// recordRemovedOperations lists the operations of the source missing from the filtered spec
+func (r *FilterReport) recordRemovedOperations(doc *openapi3.T, filtered *openapi3.T) {
+	if doc.Paths == nil {
+		return
+	}
+
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		pathItem, err := resolvePathItem(doc, path, doc.Paths.Value(path))
+		if err != nil || pathItem == nil {
+			continue
+		}
+
+		var retained map[string]*openapi3.Operation
+		if filteredItem := filtered.Paths.Value(path); filteredItem != nil {
+			retained = filteredItem.Operations()
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			if retained[method] == nil {
+				r.RemovedOperations = append(r.RemovedOperations, OperationRef{
+					Path:        path,
+					Method:      strings.ToUpper(method),
+					OperationID: operations[method].OperationID,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package openax_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterWithReport(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Report API
+  version: 1.0.0
+tags:
+  - name: users
+paths:
+  /users:
+    get:
+      tags: [users]
+      responses:
+        '200':
+          description: OK
+  /posts:
+    get:
+      operationId: listPosts
+      tags: [posts]
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("warns about undeclared tag", func(t *testing.T) {
+		filtered, report, err := client.FilterWithReport(doc, openax.FilterOptions{})
+		require.NoError(t, err, "Filter should not fail")
+		require.NotNil(t, filtered)
+		require.NotNil(t, report)
+
+		require.Len(t, report.Warnings, 1)
+		assert.Contains(t, report.Warnings[0].Message, `"posts"`)
+		require.NotNil(t, report.Warnings[0].Location)
+		assert.Equal(t, "paths./posts.get", report.Warnings[0].Location.Path)
+	})
+
+	t.Run("no warnings for declared tags", func(t *testing.T) {
+		_, report, err := client.FilterWithReport(doc, openax.FilterOptions{Tags: []string{"users"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Empty(t, report.Warnings)
+	})
+
+	t.Run("lists removed operations", func(t *testing.T) {
+		_, report, err := client.FilterWithReport(doc, openax.FilterOptions{Tags: []string{"users"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.Equal(t, []openax.OperationRef{
+			{Path: "/posts", Method: "GET", OperationID: "listPosts"},
+		}, report.RemovedOperations)
+	})
+}
+
+func TestFilterDynamicRefs(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.1.0
+info:
+  title: Trees
+  version: 1.0.0
+paths:
+  /trees:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $dynamicRef: '#node'
+  /forests:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $recursiveRef: '#'
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+        leaf:
+          $dynamicRef: '#/components/schemas/Leaf'
+    Leaf:
+      type: string
+    Unused:
+      type: object
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("resolves anchor targets", func(t *testing.T) {
+		filtered, report, err := client.FilterWithReport(doc, openax.FilterOptions{
+			Paths:           []string{"/trees"},
+			PruneComponents: true,
+		})
+		require.NoError(t, err, "Filter should not fail")
+
+		assert.ElementsMatch(t, []string{"Tree", "Leaf"}, slices.Collect(maps.Keys(filtered.Components.Schemas)))
+		assert.Empty(t, report.Warnings)
+	})
+
+	t.Run("reports unresolvable references", func(t *testing.T) {
+		_, report, err := client.FilterWithReport(doc, openax.FilterOptions{Paths: []string{"/forests"}})
+		require.NoError(t, err, "Filter should not fail")
+
+		require.Len(t, report.Warnings, 1)
+		assert.Contains(t, report.Warnings[0].Message, `$recursiveRef "#"`)
+		require.NotNil(t, report.Warnings[0].Location)
+		assert.Equal(t, "paths./forests.get.responses.200.content.application/json.schema.items", report.Warnings[0].Location.Path)
+	})
+}
@@ -0,0 +1,68 @@
+package openax
+
+import (
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// samplePathsPerTag returns a copy of the document restricted to the matched paths
+// that fit within the per-tag cap. Paths are considered in lexical order; a path
+// is kept only if every tag used by its matched operations still has room, and
+// then counts towards all of them. Untagged paths share a single bucket.
+func samplePathsPerTag(doc *openapi3.T, opts FilterOptions) (*openapi3.T, error) {
+	// Find the matched paths with the cap lifted; warnings come from the real pass
+	matchOpts := FilterOptions{
+		Paths:               opts.Paths,
+		Operations:          opts.Operations,
+		Tags:                opts.Tags,
+		TagDescriptionMatch: opts.TagDescriptionMatch,
+		Schemas:             opts.Schemas,
+		HasParameters:       opts.HasParameters,
+		RequireRequestBody:  opts.RequireRequestBody,
+		OperationSelectors:  opts.OperationSelectors,
+	}
+	matched, err := applyFilterWithReport(doc, matchOpts, &FilterReport{})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	sampled := *doc
+	sampled.Paths = &openapi3.Paths{}
+	if doc.Paths != nil {
+		sampled.Paths.Extensions = doc.Paths.Extensions
+	}
+	for _, path := range sortedKeys(matched.Paths.Map()) {
+		tags := pathTags(matched.Paths.Value(path))
+		if slices.ContainsFunc(tags, func(tag string) bool { return counts[tag] >= opts.MaxPathsPerTag }) {
+			continue
+		}
+		for _, tag := range tags {
+			counts[tag]++
+		}
+		sampled.Paths.Set(path, doc.Paths.Value(path))
+	}
+
+	return &sampled, nil
+}
+
+// pathTags returns the distinct tags used by the operations of a path item, or a
+// single empty tag if none of them is tagged
+func pathTags(pathItem *openapi3.PathItem) []string {
+	var tags []string
+	for _, operation := range pathItem.Operations() {
+		if operation == nil {
+			continue
+		}
+		for _, tag := range operation.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return []string{""}
+	}
+	return tags
+}
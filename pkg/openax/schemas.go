@@ -0,0 +1,99 @@
+package openax
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OperationRef identifies an operation by its path and method.
+type OperationRef struct {
+	Path        string `json:"path"`                  // Path of the operation
+	Method      string `json:"method"`                // Upper-case HTTP method
+	OperationID string `json:"operationId,omitempty"` // Operation identifier, if any
+}
+
+// OperationsUsingSchema reports the operations whose reference closure includes the
+// named schema, directly or through other components, i.e. the operations affected
+// if the schema were removed or changed.
+//
+// Operations are ordered by path and method. Nil is returned if the schema does not
+// exist or the references cannot be resolved.
+//
+// Example:
+//
+//	for _, op := range openax.OperationsUsingSchema(doc, "Pet") {
+//		fmt.Println(op.Method, op.Path) // e.g. "PUT /pet"
+//	}
+func OperationsUsingSchema(doc *openapi3.T, schemaName string) []OperationRef {
+	matched, err := findOperationsUsingSchemas(doc, []string{schemaName}, findAllMimeTypes(doc))
+	if err != nil {
+		return nil
+	}
+
+	var refs []OperationRef
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			if operation := operations[method]; matched[operation] {
+				refs = append(refs, OperationRef{Path: path, Method: strings.ToUpper(method), OperationID: operation.OperationID})
+			}
+		}
+	}
+	return refs
+}
+
+// findOperationsUsingSchemas returns the operations whose reference closure includes
+// any of the named schemas
+func findOperationsUsingSchemas(doc *openapi3.T, schemaNames []string, mimeTypes []string) (map[*openapi3.Operation]bool, error) {
+	for _, schemaName := range schemaNames {
+		if doc.Components == nil || doc.Components.Schemas[schemaName] == nil {
+			return nil, &ComponentNotFoundError{Name: schemaName, Type: "schema", Context: "schema filter"}
+		}
+	}
+
+	deps := buildComponentDependencies(doc.Components)
+	matched := make(map[*openapi3.Operation]bool)
+
+	for _, pathItem := range doc.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		for _, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+
+			usage := &ComponentUsage{
+				Schemas:       make(map[string]bool),
+				Parameters:    make(map[string]bool),
+				RequestBodies: make(map[string]bool),
+				Responses:     make(map[string]bool),
+			}
+			err := collectReferencesFromOperation(doc, operation, mimeTypes,
+				usage.Schemas, usage.RequestBodies, usage.Parameters, usage.Responses)
+			if err != nil {
+				return nil, err
+			}
+			// Path-level parameters apply to every operation of the path
+			shared := &openapi3.Operation{Parameters: pathItem.Parameters}
+			if err := processOperationParameters(doc, shared, usage.Schemas, usage.Parameters); err != nil {
+				return nil, err
+			}
+			expandComponentUsage(deps, usage)
+
+			for _, schemaName := range schemaNames {
+				if usage.Schemas[schemaName] {
+					matched[operation] = true
+					break
+				}
+			}
+		}
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,78 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationsUsingSchema(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromFile("../../testdata/specs/petstore.yaml")
+	require.NoError(t, err, "Failed to load spec")
+
+	assert.Equal(t, []openax.OperationRef{
+		{Path: "/pet", Method: "POST", OperationID: "addPet"},
+		{Path: "/pet", Method: "PUT", OperationID: "updatePet"},
+		{Path: "/pet/findByStatus", Method: "GET", OperationID: "findPetsByStatus"},
+		{Path: "/pet/findByTags", Method: "GET", OperationID: "findPetsByTags"},
+		{Path: "/pet/{petId}", Method: "GET", OperationID: "getPetById"},
+		{Path: "/pet/{petId}", Method: "POST", OperationID: "updatePetWithForm"},
+	}, openax.OperationsUsingSchema(doc, "Pet"))
+
+	t.Run("transitive use", func(t *testing.T) {
+		// Category is only reachable through Pet
+		assert.Equal(t, openax.OperationsUsingSchema(doc, "Pet"), openax.OperationsUsingSchema(doc, "Category"))
+	})
+
+	t.Run("path-level parameter", func(t *testing.T) {
+		doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        "200":
+          description: OK
+          links:
+            GetPet:
+              operationId: getPet
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: getPet
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    PetID:
+      name: id
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/PetIDValue'
+  schemas:
+    PetIDValue:
+      type: string
+`))
+		require.NoError(t, err, "Failed to load spec")
+
+		assert.Equal(t, []openax.OperationRef{
+			{Path: "/pets/{id}", Method: "GET", OperationID: "getPet"},
+		}, openax.OperationsUsingSchema(doc, "PetIDValue"))
+	})
+
+	t.Run("unknown schema", func(t *testing.T) {
+		assert.Nil(t, openax.OperationsUsingSchema(doc, "Unicorn"))
+	})
+}
@@ -0,0 +1,88 @@
+package openax
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// collectUsedSecuritySchemes returns the security schemes required by the filtered
+// spec, mapped to the scopes requested for each. Operations without their own
+// security requirements inherit the top-level requirements of the source document.
+func collectUsedSecuritySchemes(doc *openapi3.T, filtered *openapi3.T) map[string]map[string]bool {
+	used := make(map[string]map[string]bool)
+	addRequirements := func(requirements openapi3.SecurityRequirements) {
+		for _, requirement := range requirements {
+			for schemeName, scopes := range requirement {
+				if used[schemeName] == nil {
+					used[schemeName] = make(map[string]bool)
+				}
+				for _, scope := range scopes {
+					used[schemeName][scope] = true
+				}
+			}
+		}
+	}
+
+	addRequirements(filtered.Security)
+
+	for _, pathItem := range filtered.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			if operation.Security != nil {
+				addRequirements(*operation.Security)
+			} else {
+				addRequirements(doc.Security)
+			}
+		}
+	}
+
+	return used
+}
+
+// pruneSecuritySchemes removes unused security schemes and, for retained OAuth2
+// schemes, the scopes that no retained operation requires.
+func pruneSecuritySchemes(doc *openapi3.T, filtered *openapi3.T) {
+	used := collectUsedSecuritySchemes(doc, filtered)
+
+	for schemeName, scheme := range filtered.Components.SecuritySchemes {
+		scopes, ok := used[schemeName]
+		if !ok {
+			delete(filtered.Components.SecuritySchemes, schemeName)
+			continue
+		}
+
+		if scheme == nil || scheme.Ref != "" || scheme.Value == nil || scheme.Value.Type != "oauth2" || scheme.Value.Flows == nil {
+			continue
+		}
+
+		flows := *scheme.Value.Flows
+		flows.Implicit = pruneOAuthFlowScopes(flows.Implicit, scopes)
+		flows.Password = pruneOAuthFlowScopes(flows.Password, scopes)
+		flows.ClientCredentials = pruneOAuthFlowScopes(flows.ClientCredentials, scopes)
+		flows.AuthorizationCode = pruneOAuthFlowScopes(flows.AuthorizationCode, scopes)
+
+		value := *scheme.Value
+		value.Flows = &flows
+
+		copied := *scheme
+		copied.Value = &value
+		filtered.Components.SecuritySchemes[schemeName] = &copied
+	}
+}
+
+// pruneOAuthFlowScopes returns a copy of the flow keeping only the used scopes
+func pruneOAuthFlowScopes(flow *openapi3.OAuthFlow, used map[string]bool) *openapi3.OAuthFlow {
+	if flow == nil {
+		return nil
+	}
+
+	copied := *flow
+	copied.Scopes = make(map[string]string)
+	for scope, description := range flow.Scopes {
+		if used[scope] {
+			copied.Scopes[scope] = description
+		}
+	}
+	return &copied
+}
@@ -0,0 +1,36 @@
+package openax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// operationSelected reports whether any selector names the operation at the path
+func operationSelected(selectors []OperationSelector, path, method string) bool {
+	return slices.ContainsFunc(selectors, func(selector OperationSelector) bool {
+		return selector.Path == path && strings.EqualFold(selector.Method, method)
+	})
+}
+
+// checkOperationSelectors rejects selectors that do not name an operation of the document
+func checkOperationSelectors(doc *openapi3.T, selectors []OperationSelector) error {
+	for _, selector := range selectors {
+		var operation *openapi3.Operation
+		if doc.Paths != nil {
+			pathItem, err := resolvePathItem(doc, selector.Path, doc.Paths.Value(selector.Path))
+			if err != nil {
+				return err
+			}
+			if pathItem != nil {
+				operation = pathItem.GetOperation(strings.ToUpper(selector.Method))
+			}
+		}
+		if operation == nil {
+			return fmt.Errorf("no operation matches selector %s %s", strings.ToUpper(selector.Method), selector.Path)
+		}
+	}
+	return nil
+}
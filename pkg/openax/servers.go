@@ -0,0 +1,75 @@
+package openax
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// resolveServerVariables substitutes each server variable's default value into its
+// URL and drops the variables, at the document, path, and operation level.
+func resolveServerVariables(filtered *openapi3.T) error {
+	servers, err := resolveServers(filtered.Servers, "servers")
+	if err != nil {
+		return err
+	}
+	filtered.Servers = servers
+
+	for path, pathItem := range filtered.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		copied := *pathItem
+		if copied.Servers, err = resolveServers(pathItem.Servers, fmt.Sprintf("paths.%s.servers", path)); err != nil {
+			return err
+		}
+
+		for method, operation := range pathItem.Operations() {
+			if operation == nil || operation.Servers == nil {
+				continue
+			}
+			op := *operation
+			servers, err := resolveServers(*operation.Servers,
+				fmt.Sprintf("paths.%s.%s.servers", path, strings.ToLower(method)))
+			if err != nil {
+				return err
+			}
+			op.Servers = &servers
+			copied.SetOperation(method, &op)
+		}
+		filtered.Paths.Set(path, &copied)
+	}
+
+	return nil
+}
+
+// resolveServers returns a copy of servers with every variable substituted
+func resolveServers(servers openapi3.Servers, location string) (openapi3.Servers, error) {
+	if servers == nil {
+		return nil, nil
+	}
+	resolved := make(openapi3.Servers, len(servers))
+	for i, server := range servers {
+		if server == nil || len(server.Variables) == 0 {
+			resolved[i] = server
+			continue
+		}
+
+		url := server.URL
+		for _, name := range sortedKeys(server.Variables) {
+			variable := server.Variables[name]
+			if variable == nil || variable.Default == "" {
+				return nil, WrapError(fmt.Errorf("server variable %q has no default", name),
+					"resolving server variables", createLocation(fmt.Sprintf("%s.%d.variables.%s", location, i, name)))
+			}
+			url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+		}
+
+		copied := *server
+		copied.URL = url
+		copied.Variables = nil
+		resolved[i] = &copied
+	}
+	return resolved, nil
+}
@@ -0,0 +1,117 @@
+package openax
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultTagSection names the section holding operations without tags
+const defaultTagSection = "default"
+
+// TagSection is a table-of-contents entry grouping the operations of one tag.
+type TagSection struct {
+	Name        string     `json:"name"`                  // Tag name ("default" for untagged operations)
+	Description string     `json:"description,omitempty"` // Tag description from the top-level tags
+	Operations  []TOCEntry `json:"operations"`            // Operations using the tag, ordered by path and method
+}
+
+// TOCEntry describes a single operation in the table of contents.
+type TOCEntry struct {
+	Method      string `json:"method"`                // Upper-case HTTP method
+	Path        string `json:"path"`                  // Path of the operation
+	Summary     string `json:"summary,omitempty"`     // Operation summary
+	OperationID string `json:"operationId,omitempty"` // Operation identifier
+}
+
+// TableOfContents lists the operations selected by the filter options grouped by tag,
+// for lightweight docs navigation. No specification is built and no components are
+// resolved; only the selection filters (Paths, Operations, Tags, TagDescriptionMatch,
+// Schemas, HasParameters, RequireRequestBody, and OperationSelectors) are applied.
+//
+// Sections follow the order of the top-level tags, followed by undeclared tags in
+// lexical order and finally the "default" section for untagged operations. An
+// operation with several tags appears in each of their sections. Nil is returned if
+// the filter options are invalid.
+//
+// Example:
+//
+//	toc := openax.TableOfContents(doc, openax.FilterOptions{Tags: []string{"public"}})
+//	data, _ := json.MarshalIndent(toc, "", "  ")
+func TableOfContents(doc *openapi3.T, opts FilterOptions) []TagSection {
+	if err := validateFilterOptions(opts); err != nil {
+		return nil
+	}
+
+	matcher, err := newOperationMatcher(doc, opts, findAllMimeTypes(doc))
+	if err != nil {
+		return nil
+	}
+
+	entries := make(map[string][]TOCEntry)
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.InMatchingOrder() {
+			pathItem := doc.Paths.Value(path)
+			if pathItem == nil {
+				continue
+			}
+			wholePath := matcher.selectsPath(path)
+
+			operations := pathItem.Operations()
+			for _, method := range sortedKeys(operations) {
+				operation := operations[method]
+				if operation == nil {
+					continue
+				}
+				if !wholePath && !matcher.selects(path, method, operation) {
+					continue
+				}
+
+				entry := TOCEntry{
+					Method:      strings.ToUpper(method),
+					Path:        path,
+					Summary:     operation.Summary,
+					OperationID: operation.OperationID,
+				}
+				tags := operation.Tags
+				if len(tags) == 0 {
+					tags = []string{defaultTagSection}
+				}
+				for _, tag := range tags {
+					entries[tag] = append(entries[tag], entry)
+				}
+			}
+		}
+	}
+
+	var sections []TagSection
+	addSection := func(name, description string) {
+		operations, ok := entries[name]
+		if !ok {
+			return
+		}
+		slices.SortStableFunc(operations, func(a, b TOCEntry) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		sections = append(sections, TagSection{Name: name, Description: description, Operations: operations})
+		delete(entries, name)
+	}
+
+	for _, tag := range doc.Tags {
+		if tag != nil {
+			addSection(tag.Name, tag.Description)
+		}
+	}
+	untagged, hasUntagged := entries[defaultTagSection]
+	delete(entries, defaultTagSection)
+	for _, name := range sortedKeys(entries) {
+		addSection(name, "")
+	}
+	if hasUntagged {
+		entries[defaultTagSection] = untagged
+		addSection(defaultTagSection, "")
+	}
+
+	return sections
+}
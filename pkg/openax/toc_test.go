@@ -0,0 +1,84 @@
+package openax_test
+
+import (
+	"testing"
+
+	"github.com/imtanmoy/openax/pkg/openax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableOfContents(t *testing.T) {
+	client := openax.New()
+
+	doc, err := client.LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: TOC API
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Everything about pets
+  - name: store
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+    post:
+      tags: [pets, store]
+      summary: Create pet
+      responses:
+        '201':
+          description: Created
+  /orders:
+    get:
+      tags: [store]
+      summary: List orders
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      summary: Health check
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err, "Failed to load spec")
+
+	t.Run("groups operations by tag", func(t *testing.T) {
+		toc := openax.TableOfContents(doc, openax.FilterOptions{})
+
+		require.Len(t, toc, 3)
+		assert.Equal(t, openax.TagSection{
+			Name:        "pets",
+			Description: "Everything about pets",
+			Operations: []openax.TOCEntry{
+				{Method: "GET", Path: "/pets", Summary: "List pets", OperationID: "listPets"},
+				{Method: "POST", Path: "/pets", Summary: "Create pet"},
+			},
+		}, toc[0])
+		assert.Equal(t, openax.TagSection{
+			Name: "store",
+			Operations: []openax.TOCEntry{
+				{Method: "GET", Path: "/orders", Summary: "List orders"},
+				{Method: "POST", Path: "/pets", Summary: "Create pet"},
+			},
+		}, toc[1])
+		assert.Equal(t, "default", toc[2].Name)
+		assert.Equal(t, []openax.TOCEntry{{Method: "GET", Path: "/health", Summary: "Health check"}}, toc[2].Operations)
+	})
+
+	t.Run("applies filters", func(t *testing.T) {
+		toc := openax.TableOfContents(doc, openax.FilterOptions{Operations: []string{"post"}})
+
+		require.Len(t, toc, 2)
+		assert.Equal(t, "pets", toc[0].Name)
+		assert.Equal(t, "store", toc[1].Name)
+		assert.Len(t, toc[1].Operations, 1)
+	})
+}
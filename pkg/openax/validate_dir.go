@@ -0,0 +1,85 @@
+package openax
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// specExtensions lists the file extensions ValidateDir treats as specifications
+var specExtensions = []string{".yaml", ".yml", ".json"}
+
+// ValidationResult is the outcome of validating a single specification file.
+type ValidationResult struct {
+	Path string // Path of the specification file
+	Err  error  // Load or validation error; nil if the specification is valid
+}
+
+// Valid reports whether the specification passed validation.
+func (r ValidationResult) Valid() bool {
+	return r.Err == nil
+}
+
+// ValidateDir validates every YAML and JSON specification in a directory, descending
+// into subdirectories when recursive is true.
+//
+// Results are returned in lexical path order. A directory that cannot be read is
+// reported as a single failed result for that directory. Each file is loaded with
+// a fresh client so results do not depend on each other.
+//
+// Example:
+//
+//	for _, result := range openax.ValidateDir("specs", true) {
+//		if !result.Valid() {
+//			fmt.Printf("%s: %v\n", result.Path, result.Err)
+//		}
+//	}
+func ValidateDir(dir string, recursive bool) []ValidationResult {
+	paths, err := SpecFiles(dir, recursive)
+
+	results := make([]ValidationResult, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, ValidationResult{Path: path, Err: New().ValidateOnly(path)})
+	}
+	if err != nil {
+		results = append(results, ValidationResult{Path: dir, Err: err})
+	}
+
+	return results
+}
+
+// SpecFiles lists the YAML and JSON specifications in a directory in lexical path
+// order, descending into subdirectories when recursive is true. If the directory
+// cannot be fully read, the files found so far are returned with the error.
+func SpecFiles(dir string, recursive bool) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isSpecFile(path) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+
+	return paths, err
+}
+
+// isSpecFile reports whether a file has a specification extension
+func isSpecFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, specExt := range specExtensions {
+		if ext == specExt {
+			return true
+		}
+	}
+	return false
+}